@@ -0,0 +1,3 @@
+data "maas_dns_domain" "default" {
+  name = "maas"
+}
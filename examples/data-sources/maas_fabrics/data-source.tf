@@ -0,0 +1,5 @@
+data "maas_fabrics" "all" {}
+
+data "maas_fabrics" "tf" {
+  name_regex = "^tf-"
+}
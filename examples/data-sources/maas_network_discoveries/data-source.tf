@@ -0,0 +1,13 @@
+data "maas_network_discoveries" "provisioning" {
+  subnet       = "10.10.0.0/16"
+  unknown_only = true
+}
+
+resource "maas_device" "discovered" {
+  for_each = { for n in data.maas_network_discoveries.provisioning.discoveries : n.mac_address => n }
+
+  description = "Discovered on ${each.value.observer_interface} (${each.value.mac_organization})"
+  network_interfaces {
+    mac_address = each.value.mac_address
+  }
+}
@@ -0,0 +1,3 @@
+data "maas_space" "storage" {
+  name = "storage"
+}
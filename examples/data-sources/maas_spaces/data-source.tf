@@ -0,0 +1 @@
+data "maas_spaces" "all" {}
@@ -0,0 +1,10 @@
+data "maas_subnet_utilization" "vid10" {
+  subnet = "10.10.0.0/16"
+}
+
+resource "maas_subnet_ip_range" "reserved" {
+  subnet   = data.maas_subnet_utilization.vid10.subnet
+  type     = "reserved"
+  start_ip = data.maas_subnet_utilization.vid10.next_free_ip
+  end_ip   = data.maas_subnet_utilization.vid10.next_free_ip
+}
@@ -0,0 +1,8 @@
+data "maas_subnets" "storage" {
+  space = "storage"
+}
+
+data "maas_subnets" "untagged" {
+  fabric = data.maas_fabric.default.id
+  vid = 0
+}
@@ -0,0 +1,3 @@
+data "maas_vlans" "default" {
+  fabric = data.maas_fabric.default.id
+}
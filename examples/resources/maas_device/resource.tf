@@ -12,4 +12,9 @@ resource "maas_device" "test_device" {
   network_interfaces {
     mac_address = "12:23:45:67:89:de"
   }
+  network_interfaces {
+    mac_address = "12:23:45:67:89:df"
+    subnet      = "10.10.0.0/16"
+    ip_address  = "10.10.0.20"
+  }
 }
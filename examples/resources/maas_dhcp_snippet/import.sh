@@ -0,0 +1,2 @@
+# A DHCP snippet can be imported using its name or ID. e.g.
+$ terraform import maas_dhcp_snippet.ntp ntp-servers
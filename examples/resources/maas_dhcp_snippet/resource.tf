@@ -0,0 +1,15 @@
+resource "maas_dhcp_snippet" "ntp" {
+  name = "ntp-servers"
+  value = "option ntp-servers 10.88.88.1;"
+  description = "NTP servers for the tf_subnet subnet"
+  subnet = maas_subnet.tf_subnet.cidr
+}
+
+resource "maas_dhcp_snippet" "ipxe" {
+  name = "ipxe-chainload"
+  value = <<-EOT
+    if exists user-class and option user-class = "iPXE" {
+      filename "http://10.88.88.1/boot.ipxe";
+    }
+  EOT
+}
@@ -3,3 +3,9 @@ resource "maas_dns_domain" "cloudbase" {
   ttl = 3600
   authoritative = true
 }
+
+resource "maas_dns_domain" "corp" {
+  name                = "corp.example.com"
+  authoritative       = false
+  forward_dns_servers = ["10.0.0.53", "10.0.1.53:5353"]
+}
@@ -10,9 +10,39 @@ resource "maas_dns_record" "test_aaaa" {
   fqdn = "test-aaaa.${maas_dns_domain.cloudbase.name}"
 }
 
+resource "maas_dns_record" "test_multi" {
+  type         = "A/AAAA"
+  ip_addresses = ["10.99.11.34", "10.99.11.35"]
+  fqdn         = "test-multi.${maas_dns_domain.cloudbase.name}"
+
+  release_addresses_on_destroy = false
+}
+
 resource "maas_dns_record" "test_txt" {
   type = "TXT"
   data = "@"
   name = "test-txt"
   domain = maas_dns_domain.cloudbase.name
 }
+
+resource "maas_dns_record" "test_mx" {
+  type   = "MX"
+  name   = "test-mx"
+  domain = maas_dns_domain.cloudbase.name
+  mx {
+    preference = 10
+    exchange   = "mail.${maas_dns_domain.cloudbase.name}"
+  }
+}
+
+resource "maas_dns_record" "test_srv" {
+  type   = "SRV"
+  name   = "_sip._tcp"
+  domain = maas_dns_domain.cloudbase.name
+  srv {
+    priority = 0
+    weight   = 5
+    port     = 5060
+    target   = "sip.${maas_dns_domain.cloudbase.name}"
+  }
+}
@@ -1,3 +1,5 @@
 resource "maas_fabric" "tf_fabric" {
   name = "tf-fabric"
+  description = "Fabric managed by Terraform"
+  class_type = "10g"
 }
@@ -1,2 +1,2 @@
-# The machines imported as `maas_instance` resources must be already deployed. They can be imported using one of the deployed machine attributes: system ID, hostname, or FQDN. e.g.
+# The machines imported as `maas_instance` resources must be already deployed. They can be imported using one of the deployed machine attributes: system ID, hostname, or FQDN. The `allocate_params` (`system_id` and `hostname`) and `deploy_params` (`distro_series`, `hwe_kernel` and `enable_hw_sync`) are populated from the deployed machine. e.g.
 $ terraform import maas_instance.virsh_vm machine-01
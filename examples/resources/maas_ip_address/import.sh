@@ -0,0 +1,2 @@
+# A reserved IP address can be imported using the IP address itself. e.g.
+$ terraform import maas_ip_address.vip 10.88.88.250
@@ -0,0 +1,10 @@
+resource "maas_ip_address" "vip" {
+  subnet = maas_subnet.tf_subnet.cidr
+  ip_address = "10.88.88.250"
+  hostname = "vip.maas"
+}
+
+resource "maas_ip_address" "appliance" {
+  subnet = maas_subnet.tf_subnet.id
+  mac_address = "52:54:00:aa:bb:cc"
+}
@@ -0,0 +1,2 @@
+# A bond network interface can be imported using the machine identifier (system ID, hostname, or FQDN) and its own identifier (MAC address, name, or ID). e.g.
+$ terraform import maas_network_interface_bond.bond0 vm1:bond0
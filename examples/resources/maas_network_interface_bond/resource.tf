@@ -0,0 +1,27 @@
+resource "maas_network_interface_bond" "bond0" {
+  machine = maas_machine.virsh_vm1.id
+  name = "bond0"
+  parents = [
+    maas_network_interface_physical.virsh_vm1_nic1.name,
+    maas_network_interface_physical.virsh_vm1_nic2.name,
+  ]
+  bond_mode = "802.3ad"
+  bond_miimon = 100
+  bond_lacp_rate = "fast"
+  bond_xmit_hash_policy = "layer3+4"
+  mtu = 9000
+  vlan = data.maas_vlan.default.id
+  tags = [
+    "bond0-tag1",
+    "bond0-tag2",
+  ]
+}
+
+resource "maas_network_interface_link" "bond0" {
+  machine = maas_machine.virsh_vm1.id
+  network_interface = maas_network_interface_bond.bond0.id
+  subnet = data.maas_subnet.pxe.id
+  mode = "STATIC"
+  ip_address = "10.99.4.150"
+  default_gateway = true
+}
@@ -0,0 +1,2 @@
+# A bridge network interface can be imported using the machine identifier (system ID, hostname, or FQDN) and its own identifier (MAC address, name, or ID). e.g.
+$ terraform import maas_network_interface_bridge.br0 vm1:br0
@@ -0,0 +1,23 @@
+resource "maas_network_interface_bridge" "br0" {
+  machine = maas_machine.virsh_vm1.id
+  name = "br0"
+  parent = maas_network_interface_bond.bond0.name
+  bridge_type = "standard"
+  bridge_stp = false
+  bridge_fd = 15
+  mtu = 9000
+  vlan = data.maas_vlan.default.id
+  tags = [
+    "br0-tag1",
+    "br0-tag2",
+  ]
+}
+
+resource "maas_network_interface_link" "br0" {
+  machine = maas_machine.virsh_vm1.id
+  network_interface = maas_network_interface_bridge.br0.id
+  subnet = data.maas_subnet.pxe.id
+  mode = "STATIC"
+  ip_address = "10.99.4.151"
+  default_gateway = true
+}
@@ -0,0 +1,4 @@
+# A network interface link can be imported using the machine identifier (system ID, hostname, or FQDN), the network interface identifier (MAC address, name, or ID), and either the link ID or the CIDR of the linked subnet. e.g.
+$ terraform import maas_network_interface_link.virsh_vm1_nic1 vm1:eth0:42
+$ terraform import maas_network_interface_link.virsh_vm1_nic1 vm1:eth0:10.121.0.0/16
+$ terraform import maas_network_interface_link.virsh_vm1_nic1 vm1:52:54:00:aa:bb:cc:2001:db8::/64
@@ -0,0 +1,2 @@
+# A VLAN network interface can be imported using the machine identifier (system ID, hostname, or FQDN) and its own identifier (MAC address, name, or ID). e.g.
+$ terraform import maas_network_interface_vlan.bond0_100 vm1:bond0.100
@@ -0,0 +1,10 @@
+resource "maas_network_interface_vlan" "bond0_100" {
+  machine = maas_machine.virsh_vm1.id
+  parent = maas_network_interface_bond.bond0.name
+  fabric = maas_fabric.tf_fabric.id
+  vlan = maas_vlan.tf_vlan.vid
+  mtu = 1500
+  tags = [
+    "vlan100",
+  ]
+}
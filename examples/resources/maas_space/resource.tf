@@ -1,3 +1,4 @@
 resource "maas_space" "tf_space" {
   name = "tf-space"
+  description = "Space managed by Terraform"
 }
@@ -0,0 +1,2 @@
+# A static route can be imported using its ID. e.g.
+$ terraform import maas_static_route.to_storage 3
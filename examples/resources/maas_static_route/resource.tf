@@ -0,0 +1,6 @@
+resource "maas_static_route" "to_storage" {
+  source = maas_subnet.tf_subnet.cidr
+  destination = maas_subnet.tf_subnet_2.cidr
+  gateway_ip = "10.88.88.1"
+  metric = 10
+}
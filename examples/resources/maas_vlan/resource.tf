@@ -5,3 +5,11 @@ resource "maas_vlan" "tf_vlan" {
   space = maas_space.tf_space.name
 }
 
+
+resource "maas_vlan" "tf_vlan_relay" {
+  fabric = maas_fabric.tf_fabric.id
+  vid = 15
+  name = "tf-vlan15"
+  primary_rack = "maas-rack1"
+  relay_vlan = maas_vlan.tf_vlan.id
+}
@@ -0,0 +1,79 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/maas/gomaasclient/client"
+)
+
+func dataSourceMaasDnsDomain() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS DNS domain.",
+		ReadContext: dataSourceDnsDomainRead,
+
+		Schema: map[string]*schema.Schema{
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Boolean value indicating if the DNS domain is authoritative.",
+			},
+			"forward_dns_servers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The list of DNS servers the DNS queries for the domain are forwarded to.",
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Boolean value indicating if the DNS domain is the default in the MAAS environment.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The DNS domain identifier (name or ID).",
+			},
+			"resource_record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of DNS resource records in the DNS domain.",
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The default TTL of the DNS domain.",
+			},
+		},
+	}
+}
+
+func dataSourceDnsDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	domain, err := getDomain(client, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	details, err := getDomainDetails(client, domain.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := map[string]interface{}{
+		"id":                    fmt.Sprintf("%v", domain.ID),
+		"authoritative":         domain.Authoritative,
+		"forward_dns_servers":   details.ForwardDNSServers,
+		"is_default":            domain.IsDefault,
+		"resource_record_count": domain.ResourceRecordCount,
+		"ttl":                   domain.TTL,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package maas
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/maas/gomaasclient/client"
+)
+
+func dataSourceMaasFabrics() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a list of existing MAAS network fabrics.",
+		ReadContext: dataSourceFabricsRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabrics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of fabrics matching the filters. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"class_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fabric class type.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The fabric ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fabric name.",
+						},
+					},
+				},
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "A regular expression the fabric names must match.",
+			},
+		},
+	}
+}
+
+func dataSourceFabricsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	nameRegex, err := getNameRegexFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fabrics, err := client.Fabrics.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := []int{}
+	result := []map[string]interface{}{}
+	for _, f := range fabrics {
+		if !matchesNameRegex(nameRegex, f.Name) {
+			continue
+		}
+		ids = append(ids, f.ID)
+		result = append(result, map[string]interface{}{
+			"class_type": f.ClassType,
+			"id":         f.ID,
+			"name":       f.Name,
+		})
+	}
+	tfState := map[string]interface{}{
+		"id":      getDataSourceListID(ids),
+		"fabrics": result,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func matchesNameRegex(nameRegex *regexp.Regexp, name string) bool {
+	return nameRegex == nil || nameRegex.MatchString(name)
+}
@@ -0,0 +1,208 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/maas/gomaasclient/client"
+)
+
+// networkDiscovery is a neighbour observed by the MAAS passive network
+// discovery. gomaasclient doesn't implement the discovery endpoints, so they
+// are called through the generic API client.
+type networkDiscovery struct {
+	DiscoveryID     string `json:"discovery_id"`
+	IP              net.IP `json:"ip"`
+	MACAddress      string `json:"mac_address"`
+	MACOrganization string `json:"mac_organization"`
+	LastSeen        string `json:"last_seen"`
+	Hostname        string `json:"hostname"`
+	FabricName      string `json:"fabric_name"`
+	VID             int    `json:"vid"`
+	Observer        struct {
+		SystemID      string `json:"system_id"`
+		Hostname      string `json:"hostname"`
+		InterfaceID   int    `json:"interface_id"`
+		InterfaceName string `json:"interface_name"`
+	} `json:"observer"`
+}
+
+func dataSourceMaasNetworkDiscoveries() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a list of the neighbours (MAC and IP addresses) observed by the MAAS passive network discovery.",
+		ReadContext: dataSourceNetworkDiscoveriesRead,
+
+		Schema: map[string]*schema.Schema{
+			"discoveries": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of discovered neighbours matching the filters. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fabric": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the fabric the neighbour was observed on.",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname advertised by the neighbour over mDNS, if any.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The discovery ID.",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the neighbour.",
+						},
+						"last_seen": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date and time the neighbour was last seen.",
+						},
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAC address of the neighbour.",
+						},
+						"mac_organization": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The organization the MAC address of the neighbour is registered to.",
+						},
+						"observer": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The system ID of the rack controller which observed the neighbour.",
+						},
+						"observer_interface": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the rack controller network interface the neighbour was observed on.",
+						},
+						"vid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The traffic segregation ID of the VLAN the neighbour was observed on.",
+						},
+					},
+				},
+			},
+			"fabric": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The fabric identifier (ID or name) the neighbours must be observed on.",
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The subnet identifier (ID or CIDR) the IP addresses of the neighbours must belong to.",
+			},
+			"unknown_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return the neighbours whose MAC address is unknown to MAAS. Defaults to `false`.",
+			},
+			"vid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The VLAN traffic segregation ID the neighbours must be observed on.",
+			},
+		},
+	}
+}
+
+func dataSourceNetworkDiscoveriesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	fabricName := ""
+	if p, ok := d.GetOk("fabric"); ok {
+		fabric, err := getFabric(client, p.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		fabricName = fabric.Name
+	}
+	// The VID 0 (untagged) is a valid filter, so check if it's set in the configuration
+	vid := -1
+	if !d.GetRawConfig().GetAttr("vid").IsNull() {
+		vid = d.Get("vid").(int)
+	}
+	var cidr *net.IPNet
+	if p, ok := d.GetOk("subnet"); ok {
+		subnet, err := getSubnet(client, p.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if _, cidr, err = net.ParseCIDR(subnet.CIDR); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	discoveries, err := getNetworkDiscoveries(client, d.Get("unknown_only").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := []string{}
+	result := []map[string]interface{}{}
+	for _, n := range discoveries {
+		if (fabricName != "" && n.FabricName != fabricName) ||
+			(vid != -1 && n.VID != vid) ||
+			(cidr != nil && !cidr.Contains(n.IP)) {
+			continue
+		}
+		ipAddress := ""
+		if n.IP != nil {
+			ipAddress = n.IP.String()
+		}
+		ids = append(ids, n.DiscoveryID)
+		result = append(result, map[string]interface{}{
+			"fabric":             n.FabricName,
+			"hostname":           n.Hostname,
+			"id":                 n.DiscoveryID,
+			"ip_address":         ipAddress,
+			"last_seen":          n.LastSeen,
+			"mac_address":        n.MACAddress,
+			"mac_organization":   n.MACOrganization,
+			"observer":           n.Observer.SystemID,
+			"observer_interface": n.Observer.InterfaceName,
+			"vid":                n.VID,
+		})
+	}
+	tfState := map[string]interface{}{
+		"id":          fmt.Sprintf("%v", schema.HashString(strings.Join(ids, ","))),
+		"discoveries": result,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getNetworkDiscoveries(client *client.Client, unknownOnly bool) ([]networkDiscovery, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	op := ""
+	if unknownOnly {
+		op = "by_unknown_mac"
+	}
+	discoveries := []networkDiscovery{}
+	err = apiClient.GetSubObject("discovery").Get(op, url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, &discoveries)
+	})
+	return discoveries, err
+}
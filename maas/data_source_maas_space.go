@@ -0,0 +1,127 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/maas/gomaasclient/client"
+)
+
+func dataSourceMaasSpace() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS network space.",
+		ReadContext: dataSourceSpaceRead,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The space description.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The space identifier (name or ID).",
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The subnets of the space. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet CIDR.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The subnet ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet name.",
+						},
+					},
+				},
+			},
+			"vlans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The VLANs of the space. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fabric": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VLAN fabric.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The VLAN database ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VLAN name.",
+						},
+						"vid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The VLAN traffic segregation ID.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSpaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	space, err := findSpace(client, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if space == nil {
+		return diag.Errorf("space (%s) was not found", d.Get("name").(string))
+	}
+	details, err := getSpaceDetails(client, space.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	subnets := make([]map[string]interface{}, len(space.Subnets))
+	for i, s := range space.Subnets {
+		subnets[i] = map[string]interface{}{
+			"cidr": s.CIDR,
+			"id":   s.ID,
+			"name": s.Name,
+		}
+	}
+	vlans := make([]map[string]interface{}, len(space.VLANs))
+	for i, v := range space.VLANs {
+		vlans[i] = map[string]interface{}{
+			"fabric": v.Fabric,
+			"id":     v.ID,
+			"name":   v.Name,
+			"vid":    v.VID,
+		}
+	}
+	tfState := map[string]interface{}{
+		"id":          fmt.Sprintf("%v", space.ID),
+		"description": details.Description,
+		"subnets":     subnets,
+		"vlans":       vlans,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package maas
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/maas/gomaasclient/client"
+)
+
+func dataSourceMaasSpaces() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a list of existing MAAS network spaces.",
+		ReadContext: dataSourceSpacesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "A regular expression the space names must match.",
+			},
+			"spaces": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of spaces matching the filters. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The space ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The space name.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSpacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	nameRegex, err := getNameRegexFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	spaces, err := client.Spaces.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := []int{}
+	result := []map[string]interface{}{}
+	for _, s := range spaces {
+		if !matchesNameRegex(nameRegex, s.Name) {
+			continue
+		}
+		ids = append(ids, s.ID)
+		result = append(result, map[string]interface{}{
+			"id":   s.ID,
+			"name": s.Name,
+		})
+	}
+	tfState := map[string]interface{}{
+		"id":     getDataSourceListID(ids),
+		"spaces": result,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
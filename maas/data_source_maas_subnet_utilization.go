@@ -0,0 +1,221 @@
+package maas
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/maas/gomaasclient/client"
+	"github.com/maas/gomaasclient/entity/subnet"
+)
+
+// ipAddressAllocTypes maps the MAAS IP address allocation types to their names.
+var ipAddressAllocTypes = map[int]string{
+	0: "auto",
+	1: "sticky",
+	4: "user_reserved",
+	5: "dhcp",
+	6: "discovered",
+}
+
+func dataSourceMaasSubnetUtilization() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about the IP address utilization of an existing MAAS network subnet.",
+		ReadContext: dataSourceSubnetUtilizationRead,
+
+		Schema: map[string]*schema.Schema{
+			"available_addresses": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of available IP addresses in the subnet.",
+			},
+			"largest_available": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the largest block of available IP addresses in the subnet.",
+			},
+			"next_free_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The first IP address of the subnet that is neither used nor part of an IP range. It is empty if the subnet is full.",
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The subnet identifier (ID or CIDR).",
+			},
+			"total_addresses": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of usable IP addresses in the subnet.",
+			},
+			"unavailable_addresses": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of unavailable (used or reserved) IP addresses in the subnet.",
+			},
+			"unreserved_ip_ranges": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IP ranges of the subnet that are neither used nor reserved. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"end_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The end IP of the range.",
+						},
+						"num_addresses": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of IP addresses in the range.",
+						},
+						"start_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The start IP of the range.",
+						},
+					},
+				},
+			},
+			"usage_percent": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The percentage of unavailable IP addresses in the subnet.",
+			},
+			"used_ip_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IP addresses currently in use in the subnet. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname of the node owning the IP address, if any.",
+						},
+						"ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address.",
+						},
+						"system_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The system ID of the node owning the IP address, if any.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The allocation type of the IP address. It is one of `auto`, `sticky`, `user_reserved`, `dhcp` or `discovered`.",
+						},
+						"user": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAAS user owning the IP address, if any.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSubnetUtilizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	subnet, err := getSubnet(client, d.Get("subnet").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	stats, err := client.Subnet.GetStatistics(subnet.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ipRanges, err := client.Subnet.GetUnreservedIPRanges(subnet.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ipAddresses, err := client.Subnet.GetIPAddresses(subnet.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	unreservedIPRanges := make([]map[string]interface{}, len(ipRanges))
+	for i, r := range ipRanges {
+		unreservedIPRanges[i] = map[string]interface{}{
+			"end_ip":        r.End.String(),
+			"num_addresses": r.NumAddresses,
+			"start_ip":      r.Start.String(),
+		}
+	}
+	usedIPAddresses := make([]map[string]interface{}, len(ipAddresses))
+	for i, ip := range ipAddresses {
+		allocType, ok := ipAddressAllocTypes[ip.AllocType]
+		if !ok {
+			allocType = fmt.Sprintf("%v", ip.AllocType)
+		}
+		usedIPAddresses[i] = map[string]interface{}{
+			"hostname":  ip.NodeSummary.Hostname,
+			"ip":        ip.IP.String(),
+			"system_id": ip.NodeSummary.SystemID,
+			"type":      allocType,
+			"user":      ip.User,
+		}
+	}
+	tfState := map[string]interface{}{
+		"id":                    fmt.Sprintf("%v", subnet.ID),
+		"available_addresses":   stats.NumAvailable,
+		"largest_available":     stats.LargestAvailable,
+		"next_free_ip":          getNextFreeIP(ipRanges, ipAddresses),
+		"total_addresses":       stats.TotalAddresses,
+		"unavailable_addresses": stats.NumUnavailable,
+		"unreserved_ip_ranges":  unreservedIPRanges,
+		"usage_percent":         stats.Usage * 100,
+		"used_ip_addresses":     usedIPAddresses,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getNextFreeIP returns the first IP address of the unreserved IP ranges which
+// isn't used. The unreserved IP ranges only exclude the reserved and dynamic
+// IP ranges, not the assigned IP addresses.
+func getNextFreeIP(ipRanges []subnet.IPRange, ipAddresses []subnet.IPAddress) string {
+	used := make(map[string]bool, len(ipAddresses))
+	for _, ip := range ipAddresses {
+		used[ip.IP.String()] = true
+	}
+	for _, r := range ipRanges {
+		if r.Start == nil || r.End == nil {
+			continue
+		}
+		// A range can't contain more used IP addresses than there are in total
+		ip := r.Start
+		for i := 0; i <= len(ipAddresses) && bytes.Compare(ip.To16(), r.End.To16()) <= 0; i++ {
+			if !used[ip.String()] {
+				return ip.String()
+			}
+			ip = getNextIP(ip)
+		}
+	}
+	return ""
+}
+
+func getNextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
@@ -0,0 +1,51 @@
+package maas
+
+import (
+	"net"
+	"testing"
+
+	"github.com/maas/gomaasclient/entity/subnet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNextFreeIP(t *testing.T) {
+	ipRanges := []subnet.IPRange{
+		{Start: net.ParseIP("10.0.0.2"), End: net.ParseIP("10.0.0.3"), NumAddresses: 2},
+		{Start: net.ParseIP("10.0.0.10"), End: net.ParseIP("10.0.0.20"), NumAddresses: 11},
+	}
+
+	testCases := []struct {
+		name     string
+		used     []string
+		expected string
+	}{
+		{
+			name:     "start IP is free",
+			used:     []string{"10.0.0.1"},
+			expected: "10.0.0.2",
+		},
+		{
+			name:     "start IP is already used",
+			used:     []string{"10.0.0.2"},
+			expected: "10.0.0.3",
+		},
+		{
+			name:     "first range is fully used",
+			used:     []string{"10.0.0.2", "10.0.0.3", "10.0.0.10"},
+			expected: "10.0.0.11",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ipAddresses := make([]subnet.IPAddress, len(testCase.used))
+			for i, ip := range testCase.used {
+				ipAddresses[i] = subnet.IPAddress{IP: net.ParseIP(ip)}
+			}
+			assert.Equal(t, testCase.expected, getNextFreeIP(ipRanges, ipAddresses))
+		})
+	}
+
+	assert.Equal(t, "", getNextFreeIP(ipRanges[:1], []subnet.IPAddress{{IP: net.ParseIP("10.0.0.2")}, {IP: net.ParseIP("10.0.0.3")}}), "no free IP")
+	assert.Equal(t, "2001:db8::1:0", getNextFreeIP([]subnet.IPRange{{Start: net.ParseIP("2001:db8::ffff"), End: net.ParseIP("2001:db8::1:ffff")}}, []subnet.IPAddress{{IP: net.ParseIP("2001:db8::ffff")}}))
+}
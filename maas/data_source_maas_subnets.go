@@ -0,0 +1,152 @@
+package maas
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/maas/gomaasclient/client"
+)
+
+func dataSourceMaasSubnets() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a list of existing MAAS network subnets.",
+		ReadContext: dataSourceSubnetsRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabric": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The fabric identifier (ID or name) the subnets must belong to.",
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "A regular expression the subnet names must match.",
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the space the subnets must belong to.",
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of subnets matching the filters. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet CIDR.",
+						},
+						"fabric": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet fabric.",
+						},
+						"gateway_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Gateway IP address for the subnet.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The subnet ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet name.",
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet space.",
+						},
+						"vid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The subnet VLAN traffic segregation ID.",
+						},
+						"vlan": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The subnet VLAN database ID.",
+						},
+					},
+				},
+			},
+			"vid": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The VLAN traffic segregation ID the subnets must belong to.",
+			},
+		},
+	}
+}
+
+func dataSourceSubnetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	nameRegex, err := getNameRegexFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fabricID := -1
+	if p, ok := d.GetOk("fabric"); ok {
+		fabric, err := getFabric(client, p.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		fabricID = fabric.ID
+	}
+	// The VID 0 (untagged) is a valid filter, so check if it's set in the configuration
+	vid := -1
+	if !d.GetRawConfig().GetAttr("vid").IsNull() {
+		vid = d.Get("vid").(int)
+	}
+	space := d.Get("space").(string)
+
+	subnets, err := client.Subnets.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := []int{}
+	result := []map[string]interface{}{}
+	for _, s := range subnets {
+		if !matchesNameRegex(nameRegex, s.Name) ||
+			(fabricID != -1 && s.VLAN.FabricID != fabricID) ||
+			(vid != -1 && s.VLAN.VID != vid) ||
+			(space != "" && s.Space != space) {
+			continue
+		}
+		gatewayIp := ""
+		if s.GatewayIP != nil {
+			gatewayIp = s.GatewayIP.String()
+		}
+		ids = append(ids, s.ID)
+		result = append(result, map[string]interface{}{
+			"cidr":       s.CIDR,
+			"fabric":     s.VLAN.Fabric,
+			"gateway_ip": gatewayIp,
+			"id":         s.ID,
+			"name":       s.Name,
+			"space":      s.Space,
+			"vid":        s.VLAN.VID,
+			"vlan":       s.VLAN.ID,
+		})
+	}
+	tfState := map[string]interface{}{
+		"id":      getDataSourceListID(ids),
+		"subnets": result,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
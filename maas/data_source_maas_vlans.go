@@ -0,0 +1,98 @@
+package maas
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/maas/gomaasclient/client"
+)
+
+func dataSourceMaasVlans() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a list of existing MAAS VLANs from a fabric.",
+		ReadContext: dataSourceVlansRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabric": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The fabric identifier (ID or name) of the VLANs.",
+			},
+			"vlans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of VLANs from the fabric. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dhcp_on": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Boolean value indicating if DHCP is enabled on the VLAN.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The VLAN database ID.",
+						},
+						"mtu": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The MTU used on the VLAN.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VLAN name.",
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VLAN space.",
+						},
+						"vid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The VLAN traffic segregation ID.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVlansRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	fabric, err := getFabric(client, d.Get("fabric").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vlans, err := client.VLANs.Get(fabric.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := make([]int, len(vlans))
+	result := make([]map[string]interface{}, len(vlans))
+	for i, v := range vlans {
+		ids[i] = v.ID
+		result[i] = map[string]interface{}{
+			"dhcp_on": v.DHCPOn,
+			"id":      v.ID,
+			"mtu":     v.MTU,
+			"name":    v.Name,
+			"space":   v.Space,
+			"vid":     v.VID,
+		}
+	}
+	tfState := map[string]interface{}{
+		"id":    getDataSourceListID(ids),
+		"vlans": result,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
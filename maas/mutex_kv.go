@@ -0,0 +1,52 @@
+package maas
+
+import (
+	"log"
+	"sync"
+)
+
+// machineLocks serializes the operations which mutate the storage or the
+// network configuration of a MAAS machine. It is keyed by the machine system ID.
+var machineLocks = newMutexKV()
+
+// mutexKV is a simple key/value store for arbitrary mutexes. It can be used
+// to serialize changes across arbitrary collaborators that share knowledge of
+// the keys they must serialize on.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
+
+// Lock locks the mutex for the given key. Caller is responsible for calling
+// Unlock for the same key.
+func (m *mutexKV) Lock(key string) {
+	log.Printf("[DEBUG] Locking %q", key)
+	m.get(key).Lock()
+	log.Printf("[DEBUG] Locked %q", key)
+}
+
+// Unlock unlocks the mutex for the given key. Caller must have called Lock for
+// the same key first.
+func (m *mutexKV) Unlock(key string) {
+	log.Printf("[DEBUG] Unlocking %q", key)
+	m.get(key).Unlock()
+	log.Printf("[DEBUG] Unlocked %q", key)
+}
+
+// get returns a mutex for the given key, creating it if it doesn't exist.
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
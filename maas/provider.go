@@ -50,11 +50,17 @@ func Provider() *schema.Provider {
 			"maas_vm_host_machine":            resourceMaasVMHostMachine(),
 			"maas_machine":                    resourceMaasMachine(),
 			"maas_network_interface_physical": resourceMaasNetworkInterfacePhysical(),
+			"maas_network_interface_bond":     resourceMaasNetworkInterfaceBond(),
+			"maas_network_interface_bridge":   resourceMaasNetworkInterfaceBridge(),
+			"maas_network_interface_vlan":     resourceMaasNetworkInterfaceVlan(),
 			"maas_network_interface_link":     resourceMaasNetworkInterfaceLink(),
 			"maas_fabric":                     resourceMaasFabric(),
 			"maas_vlan":                       resourceMaasVlan(),
 			"maas_subnet":                     resourceMaasSubnet(),
 			"maas_subnet_ip_range":            resourceMaasSubnetIPRange(),
+			"maas_ip_address":                 resourceMaasIPAddress(),
+			"maas_static_route":               resourceMaasStaticRoute(),
+			"maas_dhcp_snippet":               resourceMaasDhcpSnippet(),
 			"maas_dns_domain":                 resourceMaasDnsDomain(),
 			"maas_dns_record":                 resourceMaasDnsRecord(),
 			"maas_space":                      resourceMaasSpace(),
@@ -66,6 +72,14 @@ func Provider() *schema.Provider {
 			"maas_fabric":                     dataSourceMaasFabric(),
 			"maas_vlan":                       dataSourceMaasVlan(),
 			"maas_subnet":                     dataSourceMaasSubnet(),
+			"maas_fabrics":                    dataSourceMaasFabrics(),
+			"maas_vlans":                      dataSourceMaasVlans(),
+			"maas_subnets":                    dataSourceMaasSubnets(),
+			"maas_subnet_utilization":         dataSourceMaasSubnetUtilization(),
+			"maas_spaces":                     dataSourceMaasSpaces(),
+			"maas_space":                      dataSourceMaasSpace(),
+			"maas_dns_domain":                 dataSourceMaasDnsDomain(),
+			"maas_network_discoveries":        dataSourceMaasNetworkDiscoveries(),
 			"maas_machine":                    dataSourceMaasMachine(),
 			"maas_network_interface_physical": dataSourceMaasNetworkInterfacePhysical(),
 			"maas_device":                     dataSourceMaasDevice(),
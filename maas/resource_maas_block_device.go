@@ -185,6 +185,9 @@ func resourceBlockDeviceCreate(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
 	blockDevice, err := findBlockDevice(client, machine.SystemID, d.Get("name").(string))
 	if err != nil {
 		return diag.FromErr(err)
@@ -197,7 +200,11 @@ func resourceBlockDeviceCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 	d.SetId(fmt.Sprintf("%v", blockDevice.ID))
 
-	return resourceBlockDeviceUpdate(ctx, d, meta)
+	if err := updateBlockDevice(client, d, machine.SystemID, blockDevice.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBlockDeviceRead(ctx, d, meta)
 }
 
 func resourceBlockDeviceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -242,19 +249,10 @@ func resourceBlockDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	blockDevice, err := client.BlockDevice.Update(machine.SystemID, id, getBlockDeviceParams(d))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err := setBlockDeviceTags(client, d, blockDevice); err != nil {
-		return diag.FromErr(err)
-	}
-	if p, ok := d.GetOk("is_boot_device"); ok && p.(bool) {
-		if err := client.BlockDevice.SetBootDisk(machine.SystemID, id); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-	if err := updateBlockDevicePartitions(client, d, blockDevice); err != nil {
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	if err := updateBlockDevice(client, d, machine.SystemID, id); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -272,6 +270,9 @@ func resourceBlockDeviceDelete(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
 	if err := client.BlockDevice.Delete(machine.SystemID, id); err != nil {
 		return diag.FromErr(err)
 	}
@@ -290,6 +291,22 @@ func getBlockDeviceParams(d *schema.ResourceData) *entity.BlockDeviceParams {
 	}
 }
 
+func updateBlockDevice(client *client.Client, d *schema.ResourceData, machineSystemID string, id int) error {
+	blockDevice, err := client.BlockDevice.Update(machineSystemID, id, getBlockDeviceParams(d))
+	if err != nil {
+		return err
+	}
+	if err := setBlockDeviceTags(client, d, blockDevice); err != nil {
+		return err
+	}
+	if p, ok := d.GetOk("is_boot_device"); ok && p.(bool) {
+		if err := client.BlockDevice.SetBootDisk(machineSystemID, id); err != nil {
+			return err
+		}
+	}
+	return updateBlockDevicePartitions(client, d, blockDevice)
+}
+
 func findBlockDevice(client *client.Client, machineID string, identifier string) (*entity.BlockDevice, error) {
 	blockDevices, err := client.BlockDevices.Get(machineID)
 	if err != nil {
@@ -2,9 +2,14 @@ package maas
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/maas/gomaasclient/client"
 	"github.com/maas/gomaasclient/entity"
 )
@@ -16,6 +21,7 @@ func resourceMaasDevice() *schema.Resource {
 		ReadContext:   resourceDeviceRead,
 		UpdateContext: resourceDeviceUpdate,
 		DeleteContext: resourceDeviceDelete,
+		CustomizeDiff: resourceDeviceCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				client := meta.(*client.Client)
@@ -48,8 +54,8 @@ func resourceMaasDevice() *schema.Resource {
 			"hostname": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
-				Description: "The device hostname.",
+				Computed:    true,
+				Description: "The device hostname. This argument is computed if it's not set.",
 			},
 			"ip_addresses": {
 				Type:        schema.TypeSet,
@@ -63,6 +69,7 @@ func resourceMaasDevice() *schema.Resource {
 				Type:        schema.TypeSet,
 				Required:    true,
 				Description: "A set of network interfaces attached to the device.",
+				Set:         hashDeviceNetworkInterface,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -70,16 +77,35 @@ func resourceMaasDevice() *schema.Resource {
 							Computed:    true,
 							Description: "The id of the network interface.",
 						},
+						"ip_address": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Computed:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+							Description:      "Valid IP address (from the given subnet) to be configured on the network interface. Only used when `mode` is set to `STATIC`. This argument is computed if it's not set, and computed again when `subnet` or `mode` changes.",
+						},
 						"mac_address": {
 							Type:        schema.TypeString,
 							Required:    true,
 							Description: "MAC address of the network interface.",
 						},
+						"mode": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Computed:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"AUTO", "DHCP", "STATIC", "LINK_UP"}, false)),
+							Description:      "Connection mode to the subnet. It defaults to `STATIC` when `subnet` is set. Valid options are:\n\t* `AUTO` - Random static IP address from the subnet.\n\t* `DHCP` - IP address from the DHCP on the given subnet.\n\t* `STATIC` - Use `ip_address` as static IP address, or a random one if it's not set.\n\t* `LINK_UP` - Bring the interface up only on the given subnet. No IP address will be assigned.",
+						},
 						"name": {
 							Type:        schema.TypeString,
 							Computed:    true,
 							Description: "The name of the network interface.",
 						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The identifier (CIDR or ID) of the subnet the network interface is linked to. If it's not set, the network interface isn't linked to any subnet.",
+						},
 					},
 				},
 			},
@@ -88,6 +114,19 @@ func resourceMaasDevice() *schema.Resource {
 				Computed:    true,
 				Description: "The owner of the device.",
 			},
+			"parent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The identifier (system ID, hostname, or FQDN) of the parent machine of the device (e.g. the host of a container).",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "A set of names of existing tags to be assigned to the device. The tags assigned outside of Terraform are removed, unless they are added to this set.",
+			},
 			"zone": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -98,6 +137,87 @@ func resourceMaasDevice() *schema.Resource {
 	}
 }
 
+// hashDeviceNetworkInterface identifies the device network interfaces by MAC
+// address and link. Changing the subnet or the mode of a network interface
+// replaces its item, so the IP address computed on the previous link isn't
+// kept in the plan.
+func hashDeviceNetworkInterface(v interface{}) int {
+	itemMap := v.(map[string]interface{})
+	subnet, mode := itemMap["subnet"].(string), itemMap["mode"].(string)
+	if mode == "" && subnet != "" {
+		mode = "STATIC"
+	}
+	return schema.HashString(fmt.Sprintf("%s-%s-%s", strings.ToLower(itemMap["mac_address"].(string)), subnet, mode))
+}
+
+func resourceDeviceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("network_interfaces") || !d.NewValueKnown("network_interfaces") {
+		return nil
+	}
+	items := d.Get("network_interfaces").(*schema.Set).List()
+	macAddresses := map[string]bool{}
+	for _, mac := range expandNetworkInterfacesItems(items) {
+		if macAddresses[strings.ToLower(mac)] {
+			return fmt.Errorf("network interface with MAC address (%s) is set more than once", mac)
+		}
+		macAddresses[strings.ToLower(mac)] = true
+	}
+	ipAddressSubnets := getDeviceNetworkInterfaceIPAddressSubnets(items, getDeviceConfiguredIPAddresses(d.GetRawConfig()))
+	for ipAddress, subnet := range ipAddressSubnets {
+		cidr, err := getSubnetCIDR(meta.(*client.Client), subnet)
+		if err != nil {
+			return err
+		}
+		if err := validateIPAddressInCIDR("IP address", ipAddress, cidr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDeviceConfiguredIPAddresses returns the IP addresses set in the
+// configuration of the device network interfaces, by lowercase MAC address.
+// The IP addresses computed by MAAS are left out, since they may belong to a
+// previous subnet of the network interface.
+func getDeviceConfiguredIPAddresses(rawConfig cty.Value) map[string]string {
+	ipAddresses := map[string]string{}
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return ipAddresses
+	}
+	networkInterfaces := rawConfig.GetAttr("network_interfaces")
+	if networkInterfaces.IsNull() || !networkInterfaces.IsKnown() {
+		return ipAddresses
+	}
+	for it := networkInterfaces.ElementIterator(); it.Next(); {
+		_, item := it.Element()
+		if item.IsNull() || !item.IsKnown() {
+			continue
+		}
+		mac, ipAddress := item.GetAttr("mac_address"), item.GetAttr("ip_address")
+		if mac.IsNull() || !mac.IsKnown() || ipAddress.IsNull() || !ipAddress.IsKnown() {
+			continue
+		}
+		ipAddresses[strings.ToLower(mac.AsString())] = ipAddress.AsString()
+	}
+	return ipAddresses
+}
+
+// getDeviceNetworkInterfaceIPAddressSubnets returns the subnets the configured
+// IP addresses of the device network interfaces must belong to, by IP address.
+func getDeviceNetworkInterfaceIPAddressSubnets(items []interface{}, configuredIPAddresses map[string]string) map[string]string {
+	ipAddressSubnets := map[string]string{}
+	for _, item := range items {
+		itemMap := item.(map[string]interface{})
+		ipAddress := configuredIPAddresses[strings.ToLower(itemMap["mac_address"].(string))]
+		subnet := itemMap["subnet"].(string)
+		if ipAddress == "" || subnet == "" {
+			continue
+		}
+		ipAddressSubnets[ipAddress] = subnet
+	}
+	return ipAddressSubnets
+}
+
 func expandNetworkInterfacesItems(items []interface{}) []string {
 	networkInterfacesItems := make([]string, 0)
 	for _, item := range items {
@@ -110,11 +230,17 @@ func expandNetworkInterfacesItems(items []interface{}) []string {
 func resourceDeviceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client.Client)
 
+	parent, err := getDeviceParent(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	deviceParams := entity.DeviceCreateParams{
 		Description:  d.Get("description").(string),
 		Domain:       d.Get("domain").(string),
 		Hostname:     d.Get("hostname").(string),
 		MacAddresses: expandNetworkInterfacesItems(d.Get("network_interfaces").(*schema.Set).List()),
+		Parent:       parent,
+		Zone:         d.Get("zone").(string),
 	}
 
 	device, err := client.Devices.Create(&deviceParams)
@@ -123,16 +249,28 @@ func resourceDeviceCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 	d.SetId(device.SystemID)
 
+	if err := updateDeviceNetworkInterfaceLinks(client, device, d.Get("network_interfaces").(*schema.Set).List(), getDeviceConfiguredIPAddresses(d.GetRawConfig())); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateDeviceTags(client, d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceDeviceRead(ctx, d, meta)
 }
 
 func resourceDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client.Client)
 
+	parent, err := getDeviceParent(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	deviceParams := entity.DeviceUpdateParams{
 		Description: d.Get("description").(string),
 		Domain:      d.Get("domain").(string),
 		Hostname:    d.Get("hostname").(string),
+		Parent:      parent,
 		Zone:        d.Get("zone").(string),
 	}
 
@@ -141,6 +279,29 @@ func resourceDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		return diag.FromErr(err)
 	}
 	d.SetId(device.SystemID)
+	// The parent isn't sent when it's empty, so it's cleared separately
+	if d.HasChange("parent") && parent == "" {
+		if err := clearDeviceParent(client, device.SystemID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("network_interfaces") {
+		if err := updateDeviceNetworkInterfaces(client, device, d); err != nil {
+			return diag.FromErr(err)
+		}
+		// Refresh the device to get the network interfaces added above
+		device, err = getDevice(client, device.SystemID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := updateDeviceNetworkInterfaceLinks(client, device, d.Get("network_interfaces").(*schema.Set).List(), getDeviceConfiguredIPAddresses(d.GetRawConfig())); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := updateDeviceTags(client, d); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceDeviceRead(ctx, d, meta)
 }
@@ -165,8 +326,18 @@ func resourceDeviceRead(ctx context.Context, d *schema.ResourceData, meta interf
 	d.Set("fqdn", device.FQDN)
 	d.Set("hostname", device.Hostname)
 	d.Set("owner", device.Owner)
+	d.Set("tags", device.TagNames)
 	d.Set("zone", device.Zone.Name)
 
+	// Keep the parent identifier given by the user, if it still matches
+	parent := device.Parent
+	if p := d.Get("parent").(string); p != "" && p != parent && parent != "" {
+		if machine, err := getMachine(client, p); err == nil && machine.SystemID == parent {
+			parent = p
+		}
+	}
+	d.Set("parent", parent)
+
 	ipAddresses := make([]string, len(device.IPAddresses))
 	for i, ip := range device.IPAddresses {
 		ipAddresses[i] = ip.String()
@@ -175,12 +346,34 @@ func resourceDeviceRead(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 
+	// Keep the subnet identifiers and modes given by the user, if they still match
+	configuredItems := map[string]map[string]interface{}{}
+	for _, item := range d.Get("network_interfaces").(*schema.Set).List() {
+		itemMap := item.(map[string]interface{})
+		configuredItems[strings.ToLower(itemMap["mac_address"].(string))] = itemMap
+	}
 	networkInterfaces := make([]map[string]interface{}, len(device.InterfaceSet))
 	for i, networkInterface := range device.InterfaceSet {
+		subnet, mode, ipAddress := "", "", ""
+		configuredItem, ok := configuredItems[strings.ToLower(networkInterface.MACAddress)]
+		if ok {
+			mode = configuredItem["mode"].(string)
+		}
+		if link := getDeviceNetworkInterfaceLink(&networkInterface); link != nil {
+			subnet = fmt.Sprintf("%v", link.Subnet.ID)
+			if ok {
+				subnet = flattenSubnetIdentifier(configuredItem["subnet"].(string), &link.Subnet)
+			}
+			mode = strings.ToUpper(link.Mode)
+			ipAddress = link.IPAddress
+		}
 		networkInterfaces[i] = map[string]interface{}{
 			"id":          networkInterface.ID,
+			"ip_address":  ipAddress,
 			"mac_address": networkInterface.MACAddress,
+			"mode":        mode,
 			"name":        networkInterface.Name,
+			"subnet":      subnet,
 		}
 	}
 	if err := d.Set("network_interfaces", networkInterfaces); err != nil {
@@ -189,3 +382,155 @@ func resourceDeviceRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	return nil
 }
+
+// getDeviceNetworkInterfaceLink returns the link of the device network
+// interface that is connected to a subnet, or nil if there isn't any.
+func getDeviceNetworkInterfaceLink(networkInterface *entity.NetworkInterface) *entity.NetworkInterfaceLink {
+	for _, link := range networkInterface.Links {
+		if link.Subnet.ID != 0 {
+			return &link
+		}
+	}
+	return nil
+}
+
+// updateDeviceNetworkInterfaceLinks links the device network interfaces to the
+// subnets from the configuration items. The existing links are only replaced
+// when they differ from the configuration. Only the configured IP addresses
+// are requested, the other ones are picked by MAAS.
+func updateDeviceNetworkInterfaceLinks(client *client.Client, device *entity.Device, items []interface{}, configuredIPAddresses map[string]string) error {
+	networkInterfaces := map[string]entity.NetworkInterface{}
+	for _, networkInterface := range device.InterfaceSet {
+		networkInterfaces[strings.ToLower(networkInterface.MACAddress)] = networkInterface
+	}
+	for _, item := range items {
+		itemMap := item.(map[string]interface{})
+		mac := strings.ToLower(itemMap["mac_address"].(string))
+		networkInterface, ok := networkInterfaces[mac]
+		if !ok {
+			continue
+		}
+		params := &entity.NetworkInterfaceLinkParams{}
+		if p := itemMap["subnet"].(string); p != "" {
+			subnet, err := getSubnet(client, p)
+			if err != nil {
+				return err
+			}
+			params.Subnet = subnet.ID
+			params.Mode = itemMap["mode"].(string)
+			if params.Mode == "" {
+				params.Mode = "STATIC"
+			}
+			if params.Mode == "STATIC" {
+				params.IPAddress = configuredIPAddresses[mac]
+			}
+		}
+		if isDeviceNetworkInterfaceLinkUpToDate(&networkInterface, params) {
+			continue
+		}
+		for _, l := range networkInterface.Links {
+			if l.Subnet.ID == 0 {
+				continue
+			}
+			if _, err := client.NetworkInterface.UnlinkSubnet(device.SystemID, networkInterface.ID, l.ID); err != nil {
+				return err
+			}
+		}
+		if params.Subnet == 0 {
+			continue
+		}
+		if _, err := client.NetworkInterface.LinkSubnet(device.SystemID, networkInterface.ID, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDeviceNetworkInterfaceLinkUpToDate tells if the link of the device network
+// interface already matches the link parameters.
+func isDeviceNetworkInterfaceLinkUpToDate(networkInterface *entity.NetworkInterface, params *entity.NetworkInterfaceLinkParams) bool {
+	link := getDeviceNetworkInterfaceLink(networkInterface)
+	if link == nil {
+		return params.Subnet == 0
+	}
+	return link.Subnet.ID == params.Subnet && strings.EqualFold(link.Mode, params.Mode) &&
+		(params.IPAddress == "" || normalizeIPAddress(params.IPAddress) == normalizeIPAddress(link.IPAddress))
+}
+
+func clearDeviceParent(client *client.Client, systemID string) error {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("parent", "")
+	return apiClient.GetSubObject("devices").GetSubObject(systemID).Put(params, func(data []byte) error { return nil })
+}
+
+func getDeviceParent(client *client.Client, d *schema.ResourceData) (string, error) {
+	p, ok := d.GetOk("parent")
+	if !ok {
+		return "", nil
+	}
+	machine, err := getMachine(client, p.(string))
+	if err != nil {
+		return "", err
+	}
+	return machine.SystemID, nil
+}
+
+// updateDeviceNetworkInterfaces deletes the device network interfaces whose
+// MAC addresses were removed from the configuration, and creates the ones
+// whose MAC addresses were added.
+func updateDeviceNetworkInterfaces(client *client.Client, device *entity.Device, d *schema.ResourceData) error {
+	o, n := d.GetChange("network_interfaces")
+	oldMACs := map[string]bool{}
+	for _, mac := range expandNetworkInterfacesItems(o.(*schema.Set).List()) {
+		oldMACs[strings.ToLower(mac)] = true
+	}
+	newMACs := map[string]bool{}
+	for _, mac := range expandNetworkInterfacesItems(n.(*schema.Set).List()) {
+		newMACs[strings.ToLower(mac)] = true
+	}
+	existingMACs := map[string]bool{}
+	for _, networkInterface := range device.InterfaceSet {
+		mac := strings.ToLower(networkInterface.MACAddress)
+		existingMACs[mac] = true
+		if oldMACs[mac] && !newMACs[mac] {
+			if err := client.NetworkInterface.Delete(device.SystemID, networkInterface.ID); err != nil {
+				return err
+			}
+		}
+	}
+	for mac := range newMACs {
+		if existingMACs[mac] {
+			continue
+		}
+		if _, err := client.NetworkInterfaces.CreatePhysical(device.SystemID, &entity.NetworkInterfacePhysicalParams{MACAddress: mac}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateDeviceTags(client *client.Client, d *schema.ResourceData) error {
+	if !d.HasChange("tags") {
+		return nil
+	}
+	o, n := d.GetChange("tags")
+	oldTags, newTags := o.(*schema.Set), n.(*schema.Set)
+	for _, t := range oldTags.Difference(newTags).List() {
+		if err := client.Tag.RemoveMachines(t.(string), []string{d.Id()}); err != nil {
+			return err
+		}
+	}
+	for _, t := range newTags.Difference(oldTags).List() {
+		if _, err := getTag(client, t.(string)); err != nil {
+			return err
+		}
+		if err := client.Tag.AddMachines(t.(string), []string{d.Id()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package maas
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/maas/gomaasclient/entity"
+)
+
+func TestHashDeviceNetworkInterface(t *testing.T) {
+	item := map[string]interface{}{"mac_address": "52:54:00:AA:BB:CC", "subnet": "10.0.0.0/24", "mode": "STATIC", "ip_address": "10.0.0.10"}
+
+	testCases := []struct {
+		name  string
+		item  map[string]interface{}
+		equal bool
+	}{
+		{"MAC address case", map[string]interface{}{"mac_address": "52:54:00:aa:bb:cc", "subnet": "10.0.0.0/24", "mode": "STATIC", "ip_address": "10.0.0.10"}, true},
+		{"computed IP address", map[string]interface{}{"mac_address": "52:54:00:aa:bb:cc", "subnet": "10.0.0.0/24", "mode": "STATIC", "ip_address": ""}, true},
+		{"default mode", map[string]interface{}{"mac_address": "52:54:00:aa:bb:cc", "subnet": "10.0.0.0/24", "mode": "", "ip_address": ""}, true},
+		{"subnet change", map[string]interface{}{"mac_address": "52:54:00:aa:bb:cc", "subnet": "10.0.1.0/24", "mode": "STATIC", "ip_address": "10.0.0.10"}, false},
+		{"mode change", map[string]interface{}{"mac_address": "52:54:00:aa:bb:cc", "subnet": "10.0.0.0/24", "mode": "DHCP", "ip_address": "10.0.0.10"}, false},
+	}
+	for _, tc := range testCases {
+		if equal := hashDeviceNetworkInterface(item) == hashDeviceNetworkInterface(tc.item); equal != tc.equal {
+			t.Errorf("%s: hashes equal = %v, expected %v", tc.name, equal, tc.equal)
+		}
+	}
+}
+
+func TestGetDeviceConfiguredIPAddresses(t *testing.T) {
+	networkInterfaceType := cty.Object(map[string]cty.Type{"mac_address": cty.String, "ip_address": cty.String, "subnet": cty.String})
+	rawConfig := cty.ObjectVal(map[string]cty.Value{
+		"network_interfaces": cty.SetVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"mac_address": cty.StringVal("52:54:00:AA:BB:CC"), "ip_address": cty.StringVal("10.0.0.10"), "subnet": cty.StringVal("10.0.0.0/24")}),
+			cty.ObjectVal(map[string]cty.Value{"mac_address": cty.StringVal("52:54:00:dd:ee:ff"), "ip_address": cty.NullVal(cty.String), "subnet": cty.StringVal("10.0.0.0/24")}),
+			cty.ObjectVal(map[string]cty.Value{"mac_address": cty.StringVal("52:54:00:11:22:33"), "ip_address": cty.UnknownVal(cty.String), "subnet": cty.StringVal("10.0.0.0/24")}),
+		}),
+	})
+
+	expected := map[string]string{"52:54:00:aa:bb:cc": "10.0.0.10"}
+	if actual := getDeviceConfiguredIPAddresses(rawConfig); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getDeviceConfiguredIPAddresses() = %v, expected %v", actual, expected)
+	}
+	nullConfig := cty.NullVal(cty.Object(map[string]cty.Type{"network_interfaces": cty.Set(networkInterfaceType)}))
+	if actual := getDeviceConfiguredIPAddresses(nullConfig); len(actual) != 0 {
+		t.Errorf("getDeviceConfiguredIPAddresses(null) = %v, expected no IP addresses", actual)
+	}
+}
+
+func TestGetDeviceNetworkInterfaceIPAddressSubnets(t *testing.T) {
+	items := []interface{}{
+		// IP address configured by the user
+		map[string]interface{}{"mac_address": "52:54:00:aa:bb:cc", "subnet": "10.0.0.0/24", "ip_address": "10.0.0.10"},
+		// IP address computed on the previous subnet of the network interface
+		map[string]interface{}{"mac_address": "52:54:00:dd:ee:ff", "subnet": "10.0.1.0/24", "ip_address": "10.0.0.11"},
+		// IP address configured without a subnet
+		map[string]interface{}{"mac_address": "52:54:00:11:22:33", "subnet": "", "ip_address": "10.0.0.12"},
+	}
+	configuredIPAddresses := map[string]string{"52:54:00:aa:bb:cc": "10.0.0.10", "52:54:00:11:22:33": "10.0.0.12"}
+
+	expected := map[string]string{"10.0.0.10": "10.0.0.0/24"}
+	if actual := getDeviceNetworkInterfaceIPAddressSubnets(items, configuredIPAddresses); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getDeviceNetworkInterfaceIPAddressSubnets() = %v, expected %v", actual, expected)
+	}
+}
+
+func TestIsDeviceNetworkInterfaceLinkUpToDate(t *testing.T) {
+	linked := &entity.NetworkInterface{
+		Links: []entity.NetworkInterfaceLink{
+			{ID: 1, Mode: "static", IPAddress: "10.0.0.10", Subnet: entity.Subnet{ID: 1}},
+		},
+	}
+	unlinked := &entity.NetworkInterface{
+		Links: []entity.NetworkInterfaceLink{
+			{ID: 2, Mode: "link_up"},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		networkInterface *entity.NetworkInterface
+		params           *entity.NetworkInterfaceLinkParams
+		expected         bool
+	}{
+		{"same link", linked, &entity.NetworkInterfaceLinkParams{Subnet: 1, Mode: "STATIC"}, true},
+		{"same link and IP address", linked, &entity.NetworkInterfaceLinkParams{Subnet: 1, Mode: "STATIC", IPAddress: "10.0.0.10"}, true},
+		{"different IP address", linked, &entity.NetworkInterfaceLinkParams{Subnet: 1, Mode: "STATIC", IPAddress: "10.0.0.11"}, false},
+		{"different mode", linked, &entity.NetworkInterfaceLinkParams{Subnet: 1, Mode: "DHCP"}, false},
+		{"different subnet", linked, &entity.NetworkInterfaceLinkParams{Subnet: 2, Mode: "STATIC"}, false},
+		{"link removed", linked, &entity.NetworkInterfaceLinkParams{}, false},
+		{"no link", unlinked, &entity.NetworkInterfaceLinkParams{}, true},
+		{"link added", unlinked, &entity.NetworkInterfaceLinkParams{Subnet: 1, Mode: "STATIC"}, false},
+	}
+	for _, tc := range testCases {
+		if actual := isDeviceNetworkInterfaceLinkUpToDate(tc.networkInterface, tc.params); actual != tc.expected {
+			t.Errorf("%s: isDeviceNetworkInterfaceLinkUpToDate(%+v) = %v, expected %v", tc.name, tc.params, actual, tc.expected)
+		}
+	}
+}
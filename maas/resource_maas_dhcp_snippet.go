@@ -0,0 +1,314 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/maas/gomaasclient/client"
+)
+
+// dhcpSnippet is a MAAS DHCP snippet. gomaasclient doesn't implement the DHCP
+// snippets endpoints, so they are called through the generic API client.
+type dhcpSnippet struct {
+	Name          string                   `json:"name,omitempty"`
+	Value         string                   `json:"value,omitempty"`
+	Description   string                   `json:"description,omitempty"`
+	History       []dhcpSnippetHistoryItem `json:"history,omitempty"`
+	Node          json.RawMessage          `json:"node,omitempty"`
+	Subnet        json.RawMessage          `json:"subnet,omitempty"`
+	ID            int                      `json:"id,omitempty"`
+	Enabled       bool                     `json:"enabled"`
+	GlobalSnippet bool                     `json:"global_snippet"`
+}
+
+type dhcpSnippetHistoryItem struct {
+	Value   string `json:"value,omitempty"`
+	Created string `json:"created,omitempty"`
+	ID      int    `json:"id,omitempty"`
+}
+
+// NodeSystemID returns the system ID of the snippet node, which MAAS returns
+// either as a plain string or as a node object.
+func (s *dhcpSnippet) NodeSystemID() string {
+	var systemID string
+	if err := json.Unmarshal(s.Node, &systemID); err == nil {
+		return systemID
+	}
+	var node struct {
+		SystemID string `json:"system_id"`
+	}
+	if err := json.Unmarshal(s.Node, &node); err == nil {
+		return node.SystemID
+	}
+	return ""
+}
+
+// SubnetID returns the ID of the snippet subnet, which MAAS returns either as
+// a plain ID or as a subnet object.
+func (s *dhcpSnippet) SubnetID() int {
+	var id int
+	if err := json.Unmarshal(s.Subnet, &id); err == nil {
+		return id
+	}
+	var subnet struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(s.Subnet, &subnet); err == nil {
+		return subnet.ID
+	}
+	return 0
+}
+
+func resourceMaasDhcpSnippet() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage MAAS DHCP snippets. A DHCP snippet is applied globally, to a subnet, or to a machine.",
+		CreateContext: resourceDhcpSnippetCreate,
+		ReadContext:   resourceDhcpSnippetRead,
+		UpdateContext: resourceDhcpSnippetUpdate,
+		DeleteContext: resourceDhcpSnippetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*client.Client)
+				snippet, err := getDhcpSnippet(client, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id": fmt.Sprintf("%v", snippet.ID),
+				}
+				if id := snippet.SubnetID(); id != 0 {
+					tfState["subnet"] = fmt.Sprintf("%v", id)
+				}
+				if systemID := snippet.NodeSystemID(); systemID != "" {
+					tfState["machine"] = systemID
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the DHCP snippet.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Boolean value indicating if the DHCP snippet is enabled. Defaults to `true`.",
+			},
+			"history": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The previous values of the DHCP snippet, as recorded by MAAS. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date when the value was set.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the value.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The previous value of the DHCP snippet.",
+						},
+					},
+				},
+			},
+			"machine": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"subnet"},
+				Description:   "The identifier (system ID, hostname, or FQDN) of the machine the DHCP snippet is applied to. If neither this nor `subnet` is set, the DHCP snippet is global.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the DHCP snippet.",
+			},
+			"subnet": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"machine"},
+				Description:   "The identifier (CIDR or ID) of the subnet the DHCP snippet is applied to. If neither this nor `machine` is set, the DHCP snippet is global.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The DHCP snippet value, in ISC DHCP configuration format. MAAS keeps the previous values in the `history` attribute.",
+			},
+		},
+	}
+}
+
+func resourceDhcpSnippetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	params, err := getDhcpSnippetParams(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	snippet := new(dhcpSnippet)
+	err = apiClient.GetSubObject("dhcp-snippets").Post("", params, func(data []byte) error {
+		return json.Unmarshal(data, snippet)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", snippet.ID))
+
+	return resourceDhcpSnippetRead(ctx, d, meta)
+}
+
+func resourceDhcpSnippetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	snippet, err := getDhcpSnippet(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	history := make([]map[string]interface{}, len(snippet.History))
+	for i, h := range snippet.History {
+		history[i] = map[string]interface{}{
+			"created": h.Created,
+			"id":      h.ID,
+			"value":   h.Value,
+		}
+	}
+	tfState := map[string]interface{}{
+		"description": snippet.Description,
+		"enabled":     snippet.Enabled,
+		"history":     history,
+		"name":        snippet.Name,
+		"value":       snippet.Value,
+	}
+	// Keep the subnet and machine identifiers given by the user, if they still match
+	subnet := ""
+	if id := snippet.SubnetID(); id != 0 {
+		s, err := client.Subnet.Get(id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		subnet = flattenSubnetIdentifier(d.Get("subnet").(string), s)
+	}
+	machine := snippet.NodeSystemID()
+	if p := d.Get("machine").(string); machine != "" && p != "" && p != machine {
+		if m, err := getMachine(client, p); err == nil && m.SystemID == machine {
+			machine = p
+		}
+	}
+	tfState["subnet"] = subnet
+	tfState["machine"] = machine
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDhcpSnippetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params, err := getDhcpSnippetParams(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := getDhcpSnippetAPIClient(apiClient, id).Put(params, func(data []byte) error { return nil }); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDhcpSnippetRead(ctx, d, meta)
+}
+
+func resourceDhcpSnippetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := getDhcpSnippetAPIClient(apiClient, id).Delete(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getDhcpSnippetParams(client *client.Client, d *schema.ResourceData) (url.Values, error) {
+	params := url.Values{}
+	params.Set("name", d.Get("name").(string))
+	params.Set("value", d.Get("value").(string))
+	params.Set("description", d.Get("description").(string))
+	params.Set("enabled", strconv.FormatBool(d.Get("enabled").(bool)))
+	if p, ok := d.GetOk("subnet"); ok {
+		subnet, err := getSubnet(client, p.(string))
+		if err != nil {
+			return nil, err
+		}
+		params.Set("subnet", fmt.Sprintf("%v", subnet.ID))
+	} else if p, ok := d.GetOk("machine"); ok {
+		machine, err := getMachine(client, p.(string))
+		if err != nil {
+			return nil, err
+		}
+		params.Set("node", machine.SystemID)
+	} else {
+		params.Set("global_snippet", "true")
+	}
+	return params, nil
+}
+
+func getDhcpSnippetAPIClient(apiClient client.APIClient, id int) client.APIClient {
+	return apiClient.GetSubObject("dhcp-snippets").GetSubObject(fmt.Sprintf("%v", id))
+}
+
+func getDhcpSnippet(client *client.Client, identifier string) (*dhcpSnippet, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	snippets := []dhcpSnippet{}
+	err = apiClient.GetSubObject("dhcp-snippets").Get("", url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, &snippets)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range snippets {
+		if fmt.Sprintf("%v", s.ID) == identifier || s.Name == identifier {
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("DHCP snippet (%s) was not found", identifier)
+}
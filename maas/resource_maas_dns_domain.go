@@ -2,11 +2,16 @@ package maas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/maas/gomaasclient/client"
 	"github.com/maas/gomaasclient/entity"
 )
@@ -18,6 +23,7 @@ func resourceMaasDnsDomain() *schema.Resource {
 		ReadContext:   resourceDnsDomainRead,
 		UpdateContext: resourceDnsDomainUpdate,
 		DeleteContext: resourceDnsDomainDelete,
+		CustomizeDiff: resourceDnsDomainCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				client := meta.(*client.Client)
@@ -25,12 +31,17 @@ func resourceMaasDnsDomain() *schema.Resource {
 				if err != nil {
 					return nil, err
 				}
+				details, err := getDomainDetails(client, domain.ID)
+				if err != nil {
+					return nil, err
+				}
 				tfState := map[string]interface{}{
-					"id":            fmt.Sprintf("%v", domain.ID),
-					"name":          domain.Name,
-					"ttl":           domain.TTL,
-					"authoritative": domain.Authoritative,
-					"is_default":    domain.IsDefault,
+					"id":                  fmt.Sprintf("%v", domain.ID),
+					"name":                domain.Name,
+					"ttl":                 domain.TTL,
+					"authoritative":       domain.Authoritative,
+					"forward_dns_servers": details.ForwardDNSServers,
+					"is_default":          domain.IsDefault,
 				}
 				if err := setTerraformState(d, tfState); err != nil {
 					return nil, err
@@ -46,6 +57,15 @@ func resourceMaasDnsDomain() *schema.Resource {
 				Default:     false,
 				Description: "Boolean value indicating if the new DNS domain is authoritative. Defaults to `false`.",
 			},
+			"forward_dns_servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validateForwardDNSServer),
+				},
+				Description: "The list of DNS servers (IP addresses, optionally followed by a port, e.g. `10.0.0.53` or `10.0.0.53:5353`) the DNS queries for the domain are forwarded to. It can only be used with non-authoritative domains, and requires MAAS 3.2 or later: older versions ignore them, so applying them fails.",
+			},
 			"is_default": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -85,7 +105,26 @@ func resourceDnsDomainRead(ctx context.Context, d *schema.ResourceData, meta int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if _, err := client.Domain.Get(id); err != nil {
+	details, err := getDomainDetails(client, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// MAAS versions older than 3.2 don't support forward DNS servers, so the
+	// configured ones were ignored
+	if details.ForwardDNSServers == nil {
+		if len(d.Get("forward_dns_servers").([]interface{})) == 0 {
+			return nil
+		}
+		if err := d.Set("forward_dns_servers", nil); err != nil {
+			return diag.FromErr(err)
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Forward DNS servers not supported",
+			Detail:   fmt.Sprintf("MAAS doesn't report the forward DNS servers of the DNS domain (%s), they require MAAS 3.2 or later.", d.Get("name").(string)),
+		}}
+	}
+	if err := d.Set("forward_dns_servers", flattenForwardDNSServers(convertToStringSlice(d.Get("forward_dns_servers").([]interface{})), details.ForwardDNSServers)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -99,12 +138,11 @@ func resourceDnsDomainUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	domain, err := client.Domain.Update(id, getDomainParams(d))
-	if err != nil {
+	if err := updateDomain(client, d, id); err != nil {
 		return diag.FromErr(err)
 	}
 	if d.Get("is_default").(bool) {
-		if _, err := client.Domain.SetDefault(domain.ID); err != nil {
+		if _, err := client.Domain.SetDefault(id); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -146,3 +184,154 @@ func getDomain(client *client.Client, identifier string) (*entity.Domain, error)
 	}
 	return nil, fmt.Errorf("domain (%s) was not found", identifier)
 }
+
+func resourceDnsDomainCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("authoritative").(bool) && len(d.Get("forward_dns_servers").([]interface{})) > 0 {
+		return fmt.Errorf("forward DNS servers can only be used with non-authoritative domains")
+	}
+	return nil
+}
+
+// updateDomain updates the domain through the generic API client, because
+// gomaasclient doesn't support the forward DNS servers. They are sent along
+// with the authoritative flag, so switching between the authoritative and the
+// forwarding modes happens in a single request.
+func updateDomain(client *client.Client, d *schema.ResourceData, id int) error {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("name", d.Get("name").(string))
+	if ttl := d.Get("ttl").(int); ttl > 0 {
+		params.Set("ttl", strconv.Itoa(ttl))
+	}
+	params.Set("authoritative", strconv.FormatBool(d.Get("authoritative").(bool)))
+	forwardDNSServers := convertToStringSlice(d.Get("forward_dns_servers").([]interface{}))
+	if len(forwardDNSServers) > 0 || d.HasChange("forward_dns_servers") {
+		params.Set("forward_dns_servers", strings.Join(forwardDNSServers, " "))
+	}
+	return apiClient.GetSubObject("domains").GetSubObject(fmt.Sprintf("%v", id)).Put(params, func(data []byte) error {
+		if len(forwardDNSServers) == 0 {
+			return nil
+		}
+		// MAAS versions older than 3.2 ignore the forward DNS servers
+		domain, err := parseDomainDetails(data)
+		if err != nil {
+			return err
+		}
+		if domain.ForwardDNSServers == nil {
+			return fmt.Errorf("DNS domain (%s) can't use forward DNS servers, they require MAAS 3.2 or later", domain.Name)
+		}
+		return nil
+	})
+}
+
+// domainDetails holds the domain fields which are missing from entity.Domain.
+type domainDetails struct {
+	entity.Domain
+	ForwardDNSServers []string `json:"-"`
+}
+
+func getDomainDetails(client *client.Client, id int) (*domainDetails, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	var domain *domainDetails
+	err = apiClient.GetSubObject("domains").GetSubObject(fmt.Sprintf("%v", id)).Get("", url.Values{}, func(data []byte) error {
+		var err error
+		domain, err = parseDomainDetails(data)
+		return err
+	})
+	return domain, err
+}
+
+// parseDomainDetails parses a domain returned by MAAS. The forward DNS servers
+// are nil if MAAS doesn't return them.
+func parseDomainDetails(data []byte) (*domainDetails, error) {
+	domain := new(domainDetails)
+	if err := json.Unmarshal(data, &domain.Domain); err != nil {
+		return nil, err
+	}
+	// The forward DNS servers are either IP addresses or objects with an IP address and a port
+	var raw struct {
+		ForwardDNSServers []json.RawMessage `json:"forward_dns_servers"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw.ForwardDNSServers == nil {
+		return domain, nil
+	}
+	domain.ForwardDNSServers = make([]string, len(raw.ForwardDNSServers))
+	for i, r := range raw.ForwardDNSServers {
+		var server struct {
+			IPAddress string `json:"ip_address"`
+			Port      int    `json:"port"`
+		}
+		if err := json.Unmarshal(r, &domain.ForwardDNSServers[i]); err == nil {
+			continue
+		}
+		if err := json.Unmarshal(r, &server); err != nil {
+			return nil, err
+		}
+		domain.ForwardDNSServers[i] = server.IPAddress
+		if server.Port != 0 && server.Port != 53 {
+			domain.ForwardDNSServers[i] = net.JoinHostPort(server.IPAddress, strconv.Itoa(server.Port))
+		}
+	}
+	return domain, nil
+}
+
+// parseForwardDNSServer parses a forward DNS server, given as an IP address
+// optionally followed by a port. The port defaults to 53.
+func parseForwardDNSServer(server string) (net.IP, int, error) {
+	if ip := net.ParseIP(server); ip != nil {
+		return ip, 53, nil
+	}
+	host, p, err := net.SplitHostPort(server)
+	if err != nil {
+		return nil, 0, fmt.Errorf("forward DNS server (%s) must be an IP address, optionally followed by a port", server)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, 0, fmt.Errorf("forward DNS server (%s) has an invalid IP address (%s)", server, host)
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, 0, fmt.Errorf("forward DNS server (%s) has an invalid port (%s)", server, p)
+	}
+	return ip, port, nil
+}
+
+func validateForwardDNSServer(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, _, err := parseForwardDNSServer(v); err != nil {
+		return nil, []error{err}
+	}
+	return nil, nil
+}
+
+// flattenForwardDNSServers returns the forward DNS servers from MAAS, keeping
+// the ones given by the user if they are equivalent (e.g. `10.0.0.53` and
+// `10.0.0.53:53`).
+func flattenForwardDNSServers(configured []string, servers []string) []string {
+	if len(configured) != len(servers) {
+		return servers
+	}
+	for i := range servers {
+		ip, port, err := parseForwardDNSServer(servers[i])
+		if err != nil {
+			return servers
+		}
+		configuredIP, configuredPort, err := parseForwardDNSServer(configured[i])
+		if err != nil || !ip.Equal(configuredIP) || port != configuredPort {
+			return servers
+		}
+	}
+	return configured
+}
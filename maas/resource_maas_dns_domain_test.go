@@ -0,0 +1,62 @@
+package maas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseForwardDNSServer(t *testing.T) {
+	testCases := []struct {
+		server string
+		ip     string
+		port   int
+		valid  bool
+	}{
+		{server: "10.0.0.53", ip: "10.0.0.53", port: 53, valid: true},
+		{server: "10.0.0.53:5353", ip: "10.0.0.53", port: 5353, valid: true},
+		{server: "2001:db8::53", ip: "2001:db8::53", port: 53, valid: true},
+		{server: "[2001:db8::53]:5353", ip: "2001:db8::53", port: 5353, valid: true},
+		{server: "dns.example.com"},
+		{server: "dns.example.com:53"},
+		{server: "10.0.0.53:0"},
+		{server: "10.0.0.53:65536"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.server, func(t *testing.T) {
+			ip, port, err := parseForwardDNSServer(testCase.server)
+			if !testCase.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.ip, ip.String())
+			assert.Equal(t, testCase.port, port)
+		})
+	}
+}
+
+func TestFlattenForwardDNSServers(t *testing.T) {
+	assert.Equal(t, []string{"10.0.0.53", "10.0.0.54:5353"}, flattenForwardDNSServers([]string{"10.0.0.53", "10.0.0.54:5353"}, []string{"10.0.0.53:53", "10.0.0.54:5353"}))
+	assert.Equal(t, []string{"10.0.0.55"}, flattenForwardDNSServers([]string{"10.0.0.53"}, []string{"10.0.0.55"}))
+	assert.Equal(t, []string{"10.0.0.53"}, flattenForwardDNSServers([]string{}, []string{"10.0.0.53"}))
+}
+
+func TestParseDomainDetails(t *testing.T) {
+	// MAAS 3.2 or later
+	domain, err := parseDomainDetails([]byte(`{"id": 1, "name": "example.com", "ttl": null, "authoritative": false, "is_default": false, "forward_dns_servers": ["10.0.0.53", {"ip_address": "10.0.0.54", "port": 5353}], "resource_record_count": 0, "resource_uri": "/MAAS/api/2.0/domains/1/"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", domain.Name)
+	assert.Equal(t, []string{"10.0.0.53", "10.0.0.54:5353"}, domain.ForwardDNSServers)
+
+	domain, err = parseDomainDetails([]byte(`{"id": 1, "name": "example.com", "authoritative": true, "forward_dns_servers": []}`))
+	assert.NoError(t, err)
+	assert.NotNil(t, domain.ForwardDNSServers)
+	assert.Empty(t, domain.ForwardDNSServers)
+
+	// MAAS older than 3.2
+	domain, err = parseDomainDetails([]byte(`{"id": 1, "name": "example.com", "ttl": null, "authoritative": false, "is_default": false, "resource_record_count": 0, "resource_uri": "/MAAS/api/2.0/domains/1/"}`))
+	assert.NoError(t, err)
+	assert.Nil(t, domain.ForwardDNSServers)
+}
@@ -3,18 +3,40 @@ package maas
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/juju/gomaasapi/v2"
 	"github.com/maas/gomaasclient/client"
 	"github.com/maas/gomaasclient/entity"
 )
 
 var (
 	validDnsRecordTypes = []string{"A/AAAA", "CNAME", "MX", "NS", "SRV", "SSHFP", "TXT"}
+
+	hexadecimalRegexp = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+	// dnsRecordDataBlocks maps the DNS record types to their structured data block.
+	dnsRecordDataBlocks = map[string]string{
+		"MX":    "mx",
+		"SRV":   "srv",
+		"SSHFP": "sshfp",
+		"TXT":   "txt",
+	}
+
+	// sshfpFingerprintLengths are the hexadecimal lengths of the SSHFP fingerprint types.
+	sshfpFingerprintLengths = map[int]int{
+		1: 40,
+		2: 64,
+	}
 )
 
 func resourceMaasDnsRecord() *schema.Resource {
@@ -24,6 +46,15 @@ func resourceMaasDnsRecord() *schema.Resource {
 		ReadContext:   resourceDnsRecordRead,
 		UpdateContext: resourceDnsRecordUpdate,
 		DeleteContext: resourceDnsRecordDelete,
+		CustomizeDiff: resourceDnsRecordCustomizeDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceMaasDnsRecordResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceMaasDnsRecordStateUpgradeV0,
+				Version: 0,
+			},
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), ":")
@@ -42,16 +73,14 @@ func resourceMaasDnsRecord() *schema.Resource {
 					if err != nil {
 						return nil, err
 					}
-					ips := []string{}
-					for _, ipAddress := range dnsRecord.IPAddresses {
-						ips = append(ips, ipAddress.IP.String())
-					}
+					ips := getDnsResourceIPAddresses(dnsRecord)
 					tfState = map[string]interface{}{
-						"id":   fmt.Sprintf("%v", dnsRecord.ID),
-						"type": resourceType,
-						"data": strings.Join(ips, " "),
-						"fqdn": dnsRecord.FQDN,
-						"ttl":  dnsRecord.AddressTTL,
+						"id":           fmt.Sprintf("%v", dnsRecord.ID),
+						"type":         resourceType,
+						"data":         strings.Join(ips, " "),
+						"ip_addresses": ips,
+						"fqdn":         dnsRecord.FQDN,
+						"ttl":          dnsRecord.AddressTTL,
 					}
 				} else {
 					dnsRecord, err := getDnsResourceRecord(client, resourceIdentifier)
@@ -65,6 +94,9 @@ func resourceMaasDnsRecord() *schema.Resource {
 						"fqdn": dnsRecord.FQDN,
 						"ttl":  dnsRecord.TTL,
 					}
+					if block, ok := dnsRecordDataBlocks[dnsRecord.RRType]; ok {
+						tfState[block] = flattenDnsRecordData(dnsRecord.RRType, dnsRecord.RRData)
+					}
 				}
 				if err := setTerraformState(d, tfState); err != nil {
 					return nil, err
@@ -75,9 +107,11 @@ func resourceMaasDnsRecord() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"data": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The data set for the new DNS record.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"data", "ip_addresses", "mx", "srv", "sshfp", "txt"},
+				Description:  "The data set for the new DNS record. For the `A/AAAA` type, it is a space separated list of IP addresses, and the `ip_addresses` set can be used instead. For the `MX`, `SRV`, `SSHFP` and `TXT` types, the structured `mx`, `srv`, `sshfp` and `txt` blocks can be used instead. This argument is computed if it's not set.",
 			},
 			"domain": {
 				Type:         schema.TypeString,
@@ -91,6 +125,43 @@ func resourceMaasDnsRecord() *schema.Resource {
 				ExactlyOneOf: []string{"name", "fqdn"},
 				Description:  "The fully qualified domain name of the new DNS record. This contains the name and the domain of the new DNS record. It conflicts with `name` and `domain` arguments.",
 			},
+			"ip_addresses": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"data", "ip_addresses", "mx", "srv", "sshfp", "txt"},
+				Set:          hashIPAddress,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+					StateFunc:        normalizeIPAddress,
+				},
+				Description: "A set of IP addresses of an `A/AAAA` DNS record. Unlike `data`, their order doesn't matter. It conflicts with `data` argument. This argument is computed if it's not set.",
+			},
+			"mx": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"data", "ip_addresses", "mx", "srv", "sshfp", "txt"},
+				Description:  "The data of a `MX` DNS record. It conflicts with `data` argument. Parameters defined below. This argument is computed if it's not set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"exchange": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+							Description:      "The hostname of the mail server.",
+						},
+						"preference": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 65535)),
+							Description:      "The preference of the mail server. Lower values are preferred.",
+						},
+					},
+				},
+			},
 			"name": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -98,6 +169,86 @@ func resourceMaasDnsRecord() *schema.Resource {
 				ExactlyOneOf: []string{"name", "fqdn"},
 				Description:  "The new DNS record resource name. Used in conjunction with `domain`. It conflicts with `fqdn` argument.",
 			},
+			"release_addresses_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Boolean value. When enabled, the IP addresses reserved by MAAS for an `A/AAAA` DNS record (see `reserved_ip_addresses`) are released when they are removed from the record, or when the record is destroyed. The IP addresses already known to MAAS, like the ones reserved with `maas_ip_address` or assigned to machines, are never released. The IP addresses of the records created by provider versions without this argument were reserved before they could be tracked, so they are never released. Defaults to `true`.",
+			},
+			"reserved_ip_addresses": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The IP addresses of an `A/AAAA` DNS record that were reserved by MAAS for this record, because they weren't known to MAAS before.",
+			},
+			"srv": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"data", "ip_addresses", "mx", "srv", "sshfp", "txt"},
+				Description:  "The data of a `SRV` DNS record. It conflicts with `data` argument. Parameters defined below. This argument is computed if it's not set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 65535)),
+							Description:      "The port of the service.",
+						},
+						"priority": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 65535)),
+							Description:      "The priority of the target host. Lower values are preferred.",
+						},
+						"target": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+							Description:      "The hostname of the target host.",
+						},
+						"weight": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 65535)),
+							Description:      "The relative weight of the target hosts with the same priority.",
+						},
+					},
+				},
+			},
+			"sshfp": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"data", "ip_addresses", "mx", "srv", "sshfp", "txt"},
+				Description:  "The data of a `SSHFP` DNS record. It conflicts with `data` argument. Parameters defined below. This argument is computed if it's not set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"algorithm": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 6)),
+							Description:      "The algorithm of the SSH public key (e.g. `1` for RSA, `3` for ECDSA, `4` for Ed25519).",
+						},
+						"fingerprint": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(hexadecimalRegexp, "must be a hexadecimal string")),
+							Description:      "The hexadecimal fingerprint of the SSH public key.",
+						},
+						"fingerprint_type": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 2)),
+							Description:      "The type of the fingerprint: `1` for SHA-1, `2` for SHA-256.",
+						},
+					},
+				},
+			},
 			"ttl": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -110,6 +261,24 @@ func resourceMaasDnsRecord() *schema.Resource {
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validDnsRecordTypes, false)),
 				Description:      "The DNS record type. Valid options are: `A/AAAA`, `CNAME`, `MX`, `NS`, `SRV`, `SSHFP`, `TXT`.",
 			},
+			"txt": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"data", "ip_addresses", "mx", "srv", "sshfp", "txt"},
+				Description:  "The data of a `TXT` DNS record. It conflicts with `data` argument. Parameters defined below. This argument is computed if it's not set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"text": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+							Description:      "The text of the record.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -119,13 +288,25 @@ func resourceDnsRecordCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 	var resourceID int
 	if d.Get("type").(string) == "A/AAAA" {
-		dnsRecord, err := client.DNSResources.Create(getDnsResourceParams(d))
+		params := getDnsResourceParams(d)
+		unknownIPs, err := getUnknownIPAddresses(client, strings.Fields(params.IPAddresses))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dnsRecord, err := client.DNSResources.Create(params)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		resourceID = dnsRecord.ID
+		if err := d.Set("reserved_ip_addresses", getDnsResourceReservedIPAddresses(dnsRecord, unknownIPs)); err != nil {
+			return diag.FromErr(err)
+		}
 	} else {
-		dnsRecord, err := client.DNSResourceRecords.Create(getDnsResourceRecordParams(d))
+		params, err := getDnsResourceRecordParams(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dnsRecord, err := client.DNSResourceRecords.Create(params)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -144,11 +325,37 @@ func resourceDnsRecordRead(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 	if d.Get("type").(string) == "A/AAAA" {
-		if _, err := client.DNSResource.Get(id); err != nil {
+		dnsRecord, err := client.DNSResource.Get(id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ips := getDnsResourceIPAddresses(dnsRecord)
+		// Keep the data given by the user, if it has the same IP addresses in a different order
+		data := strings.Join(ips, " ")
+		if p := d.Get("data").(string); newStringSet(normalizeIPAddresses(strings.Fields(p))).Equal(newStringSet(ips)) {
+			data = p
+		}
+		tfState := map[string]interface{}{
+			"data":         data,
+			"ip_addresses": ips,
+		}
+		// Forget the reserved IP addresses which are no longer part of the record
+		tfState["reserved_ip_addresses"] = d.Get("reserved_ip_addresses").(*schema.Set).Intersection(newStringSet(ips)).List()
+		if err := setTerraformState(d, tfState); err != nil {
 			return diag.FromErr(err)
 		}
 	} else {
-		if _, err := client.DNSResourceRecord.Get(id); err != nil {
+		dnsRecord, err := client.DNSResourceRecord.Get(id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tfState := map[string]interface{}{
+			"data": dnsRecord.RRData,
+		}
+		if block, ok := dnsRecordDataBlocks[dnsRecord.RRType]; ok {
+			tfState[block] = flattenDnsRecordData(dnsRecord.RRType, dnsRecord.RRData)
+		}
+		if err := setTerraformState(d, tfState); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -164,11 +371,15 @@ func resourceDnsRecordUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.FromErr(err)
 	}
 	if d.Get("type").(string) == "A/AAAA" {
-		if _, err := client.DNSResource.Update(id, getDnsResourceParams(d)); err != nil {
+		if err := updateDnsResource(client, d, id); err != nil {
 			return diag.FromErr(err)
 		}
 	} else {
-		if _, err := client.DNSResourceRecord.Update(id, getDnsResourceRecordParams(d)); err != nil {
+		params, err := getDnsResourceRecordParams(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := client.DNSResourceRecord.Update(id, params); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -184,17 +395,11 @@ func resourceDnsRecordDelete(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.FromErr(err)
 	}
 	if d.Get("type").(string) == "A/AAAA" {
-		dnsResource, err := client.DNSResource.Get(id)
-		if err != nil {
-			return diag.FromErr(err)
-		}
 		if err := client.DNSResource.Delete(id); err != nil {
 			return diag.FromErr(err)
 		}
-		for _, ipAddress := range dnsResource.IPAddresses {
-			if err := client.IPAddresses.Release(&entity.IPAddressesParams{IP: ipAddress.IP.String()}); err != nil {
-				return diag.FromErr(err)
-			}
+		if err := releaseIPAddresses(client, getDnsResourceReleasedIPAddresses(d)); err != nil {
+			return diag.FromErr(err)
 		}
 	} else {
 		if err := client.DNSResourceRecord.Delete(id); err != nil {
@@ -206,8 +411,16 @@ func resourceDnsRecordDelete(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func getDnsResourceParams(d *schema.ResourceData) *entity.DNSResourceParams {
+	// The IP addresses are computed from the data and vice versa, so only use
+	// the attribute set in the configuration
+	ips := strings.Fields(d.Get("data").(string))
+	if isDnsRecordAttributeConfigured(d.GetRawConfig(), "ip_addresses") {
+		ips = convertToStringSlice(d.Get("ip_addresses").(*schema.Set).List())
+	}
+	ips = normalizeIPAddresses(ips)
+	sort.Strings(ips)
 	return &entity.DNSResourceParams{
-		IPAddresses: d.Get("data").(string),
+		IPAddresses: strings.Join(ips, " "),
 		Name:        d.Get("name").(string),
 		Domain:      d.Get("domain").(string),
 		FQDN:        d.Get("fqdn").(string),
@@ -215,15 +428,145 @@ func getDnsResourceParams(d *schema.ResourceData) *entity.DNSResourceParams {
 	}
 }
 
-func getDnsResourceRecordParams(d *schema.ResourceData) *entity.DNSResourceRecordParams {
+func getDnsResourceRecordParams(d *schema.ResourceData) (*entity.DNSResourceRecordParams, error) {
+	rrData := d.Get("data").(string)
+	// The structured data blocks are computed from the data, so only use the
+	// one set in the configuration
+	rrType := d.Get("type").(string)
+	if block, ok := dnsRecordDataBlocks[rrType]; ok && isDnsRecordAttributeConfigured(d.GetRawConfig(), block) {
+		var err error
+		rrData, err = expandDnsRecordData(rrType, d.Get(block+".0").(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &entity.DNSResourceRecordParams{
-		RRType: d.Get("type").(string),
-		RRData: d.Get("data").(string),
+		RRType: rrType,
+		RRData: rrData,
 		Name:   d.Get("name").(string),
 		Domain: d.Get("domain").(string),
 		FQDN:   d.Get("fqdn").(string),
 		TTL:    d.Get("ttl").(int),
+	}, nil
+}
+
+func resourceDnsRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+	rrType := d.Get("type").(string)
+	ipAddressesConfigured := isDnsRecordAttributeConfigured(d.GetRawConfig(), "ip_addresses")
+	if ipAddressesConfigured && rrType != "A/AAAA" {
+		return fmt.Errorf("the %q argument can only be used with the %q DNS record type", "ip_addresses", "A/AAAA")
+	}
+	for recordType, block := range dnsRecordDataBlocks {
+		if !isDnsRecordAttributeConfigured(d.GetRawConfig(), block) {
+			continue
+		}
+		if recordType != rrType {
+			return fmt.Errorf("the %q block can only be used with the %q DNS record type", block, recordType)
+		}
+		if _, err := expandDnsRecordData(recordType, d.Get(block+".0").(map[string]interface{})); err != nil {
+			return err
+		}
+		// The data is computed from the structured data block
+		if d.HasChange(block) {
+			return d.SetNewComputed("data")
+		}
+		return nil
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	// The data and the IP addresses of A/AAAA records are computed from each other
+	if ipAddressesConfigured {
+		if d.HasChange("ip_addresses") {
+			return d.SetNewComputed("data")
+		}
+		return nil
+	}
+	if rrType == "A/AAAA" && d.HasChange("data") {
+		return d.SetNewComputed("ip_addresses")
+	}
+	// The structured data block is computed from the data
+	if block, ok := dnsRecordDataBlocks[rrType]; ok && d.HasChange("data") {
+		return d.SetNewComputed(block)
+	}
+	return nil
+}
+
+func isDnsRecordAttributeConfigured(rawConfig cty.Value, attribute string) bool {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return false
+	}
+	v := rawConfig.GetAttr(attribute)
+	return !v.IsNull() && v.IsKnown() && v.LengthInt() > 0
+}
+
+// expandDnsRecordData serializes a structured data block into the format
+// MAAS expects for the given DNS record type.
+func expandDnsRecordData(rrType string, data map[string]interface{}) (string, error) {
+	switch rrType {
+	case "MX":
+		return fmt.Sprintf("%v %s", data["preference"], data["exchange"]), nil
+	case "SRV":
+		return fmt.Sprintf("%v %v %v %s", data["priority"], data["weight"], data["port"], data["target"]), nil
+	case "SSHFP":
+		fingerprint := data["fingerprint"].(string)
+		// The fingerprint may be unknown at plan time, so only check the length of hexadecimal ones
+		if hexadecimalRegexp.MatchString(fingerprint) {
+			if length, ok := sshfpFingerprintLengths[data["fingerprint_type"].(int)]; ok && len(fingerprint) != length {
+				return "", fmt.Errorf("SSHFP fingerprint (%s) must be %v hexadecimal characters long for the fingerprint type %v", fingerprint, length, data["fingerprint_type"])
+			}
+		}
+		return fmt.Sprintf("%v %v %s", data["algorithm"], data["fingerprint_type"], fingerprint), nil
+	case "TXT":
+		return data["text"].(string), nil
 	}
+	return "", fmt.Errorf("DNS record type (%s) has no structured data", rrType)
+}
+
+// flattenDnsRecordData parses the data of a DNS record into its structured
+// data block. It returns an empty list if the data has an unexpected format.
+func flattenDnsRecordData(rrType string, rrData string) []map[string]interface{} {
+	fields := strings.Fields(rrData)
+	atoi := func(indexes ...int) ([]int, bool) {
+		values := make([]int, len(indexes))
+		for i, index := range indexes {
+			v, err := strconv.Atoi(fields[index])
+			if err != nil {
+				return nil, false
+			}
+			values[i] = v
+		}
+		return values, true
+	}
+	switch rrType {
+	case "MX":
+		if len(fields) != 2 {
+			break
+		}
+		if v, ok := atoi(0); ok {
+			return []map[string]interface{}{{"preference": v[0], "exchange": fields[1]}}
+		}
+	case "SRV":
+		if len(fields) != 4 {
+			break
+		}
+		if v, ok := atoi(0, 1, 2); ok {
+			return []map[string]interface{}{{"priority": v[0], "weight": v[1], "port": v[2], "target": fields[3]}}
+		}
+	case "SSHFP":
+		if len(fields) != 3 {
+			break
+		}
+		if v, ok := atoi(0, 1); ok {
+			return []map[string]interface{}{{"algorithm": v[0], "fingerprint_type": v[1], "fingerprint": fields[2]}}
+		}
+	case "TXT":
+		return []map[string]interface{}{{"text": rrData}}
+	}
+	return []map[string]interface{}{}
 }
 
 func getDnsResourceRecord(client *client.Client, identifier string) (*entity.DNSResourceRecord, error) {
@@ -251,3 +594,102 @@ func getDnsResource(client *client.Client, identifier string) (*entity.DNSResour
 	}
 	return nil, fmt.Errorf("DNS resource (%s) was not found", identifier)
 }
+
+func getDnsResourceIPAddresses(dnsResource *entity.DNSResource) []string {
+	ips := make([]string, len(dnsResource.IPAddresses))
+	for i, ipAddress := range dnsResource.IPAddresses {
+		ips[i] = ipAddress.IP.String()
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+// getUnknownIPAddresses returns the IP addresses that aren't known to MAAS
+// yet. MAAS reserves them when they are added to a DNS resource.
+func getUnknownIPAddresses(client *client.Client, ips []string) ([]string, error) {
+	unknownIPs := []string{}
+	// Listing the IP addresses of all users requires admin privileges, so
+	// non-admin users only check their own IP addresses
+	all := true
+	for _, ip := range ips {
+		ipAddresses, err := client.IPAddresses.Get(&entity.IPAddressesParams{IP: ip, All: all})
+		if serverError, ok := gomaasapi.GetServerError(err); ok && all && serverError.StatusCode == http.StatusForbidden {
+			all = false
+			ipAddresses, err = client.IPAddresses.Get(&entity.IPAddressesParams{IP: ip})
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(ipAddresses) == 0 {
+			unknownIPs = append(unknownIPs, ip)
+		}
+	}
+	return unknownIPs, nil
+}
+
+// getDnsResourceReservedIPAddresses returns the IP addresses of the DNS
+// resource that were unknown to MAAS, and got reserved by MAAS for it.
+func getDnsResourceReservedIPAddresses(dnsResource *entity.DNSResource, unknownIPs []string) []string {
+	reservedIPs := []string{}
+	for _, ipAddress := range dnsResource.IPAddresses {
+		if ipAddressAllocTypes[ipAddress.AllocType] != "user_reserved" {
+			continue
+		}
+		for _, ip := range unknownIPs {
+			if ipAddress.IP.Equal(net.ParseIP(ip)) {
+				reservedIPs = append(reservedIPs, ipAddress.IP.String())
+				break
+			}
+		}
+	}
+	return reservedIPs
+}
+
+// updateDnsResource updates the DNS resource, and keeps track of the IP
+// addresses MAAS reserved for it. The reserved IP addresses removed from the
+// DNS resource are released if release_addresses_on_destroy is enabled.
+func updateDnsResource(client *client.Client, d *schema.ResourceData, id int) error {
+	dnsResource, err := client.DNSResource.Get(id)
+	if err != nil {
+		return err
+	}
+	params := getDnsResourceParams(d)
+	ips := newStringSet(strings.Fields(params.IPAddresses))
+	currentIPs := newStringSet(getDnsResourceIPAddresses(dnsResource))
+	unknownIPs, err := getUnknownIPAddresses(client, convertToStringSlice(ips.Difference(currentIPs).List()))
+	if err != nil {
+		return err
+	}
+	if dnsResource, err = client.DNSResource.Update(id, params); err != nil {
+		return err
+	}
+	reservedIPs := d.Get("reserved_ip_addresses").(*schema.Set)
+	if d.Get("release_addresses_on_destroy").(bool) {
+		if err := releaseIPAddresses(client, convertToStringSlice(reservedIPs.Difference(ips).List())); err != nil {
+			return err
+		}
+	}
+	reservedIPs = reservedIPs.Intersection(ips)
+	for _, ip := range getDnsResourceReservedIPAddresses(dnsResource, unknownIPs) {
+		reservedIPs.Add(ip)
+	}
+	return d.Set("reserved_ip_addresses", reservedIPs.List())
+}
+
+func releaseIPAddresses(client *client.Client, ips []string) error {
+	for _, ip := range ips {
+		if err := client.IPAddresses.Release(&entity.IPAddressesParams{IP: ip}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDnsResourceReleasedIPAddresses returns the IP addresses to release when
+// the DNS resource is destroyed.
+func getDnsResourceReleasedIPAddresses(d *schema.ResourceData) []string {
+	if !d.Get("release_addresses_on_destroy").(bool) {
+		return nil
+	}
+	return convertToStringSlice(d.Get("reserved_ip_addresses").(*schema.Set).List())
+}
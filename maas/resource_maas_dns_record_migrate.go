@@ -0,0 +1,65 @@
+package maas
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMaasDnsRecordResourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"data": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The data set for the new DNS record.",
+			},
+			"domain": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"name"},
+				Description:  "The domain of the new DNS record. Used in conjunction with `name`. It conflicts with `fqdn` argument.",
+			},
+			"fqdn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "fqdn"},
+				Description:  "The fully qualified domain name of the new DNS record. This contains the name and the domain of the new DNS record. It conflicts with `name` and `domain` arguments.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"domain"},
+				ExactlyOneOf: []string{"name", "fqdn"},
+				Description:  "The new DNS record resource name. Used in conjunction with `domain`. It conflicts with `fqdn` argument.",
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The TTL of the new DNS record.",
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validDnsRecordTypes, false)),
+				Description:      "The DNS record type. Valid options are: `A/AAAA`, `CNAME`, `MX`, `NS`, `SRV`, `SSHFP`, `TXT`.",
+			},
+		},
+	}
+}
+
+func resourceMaasDnsRecordStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	// The IP addresses MAAS reserved for the record weren't tracked, so they
+	// can't be told apart from the ones reserved by other means anymore. Start
+	// tracking with none of them, so they are never released.
+	if _, ok := rawState["reserved_ip_addresses"]; !ok {
+		rawState["reserved_ip_addresses"] = []interface{}{}
+	}
+	if _, ok := rawState["release_addresses_on_destroy"]; !ok {
+		rawState["release_addresses_on_destroy"] = true
+	}
+
+	return rawState, nil
+}
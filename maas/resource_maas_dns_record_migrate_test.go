@@ -0,0 +1,79 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testResourceMaasDnsRecordInstanceStateDataV0() map[string]interface{} {
+	return map[string]interface{}{
+		"id":     "12",
+		"type":   "A/AAAA",
+		"data":   "10.0.0.10 10.0.0.11",
+		"name":   "www",
+		"domain": "example.com",
+		"fqdn":   "",
+		"ttl":    0,
+	}
+}
+
+func testResourceMaasDnsRecordInstanceStateDataV1() map[string]interface{} {
+	state := testResourceMaasDnsRecordInstanceStateDataV0()
+	state["reserved_ip_addresses"] = []interface{}{}
+	state["release_addresses_on_destroy"] = true
+	return state
+}
+
+func TestResourceMaasDnsRecordInstanceStateUpgradeV0(t *testing.T) {
+	ctx := context.Background()
+	expected := testResourceMaasDnsRecordInstanceStateDataV1()
+	actual, err := resourceMaasDnsRecordStateUpgradeV0(ctx, testResourceMaasDnsRecordInstanceStateDataV0(), nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
+
+// The reserved IP addresses of an upgraded state must be an empty set, not a
+// null value, so the upgraded records are tracked like the new ones.
+func TestResourceMaasDnsRecordUpgradeResourceState(t *testing.T) {
+	ctx := context.Background()
+	provider := Provider()
+	server := schema.NewGRPCProviderServer(provider)
+	rawState, err := json.Marshal(testResourceMaasDnsRecordInstanceStateDataV0())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "maas_dns_record",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: rawState},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %+v", resp.Diagnostics[0])
+	}
+	ty := provider.ResourcesMap["maas_dns_record"].CoreConfigSchema().ImpliedType()
+	state, err := msgpack.Unmarshal(resp.UpgradedState.MsgPack, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reservedIPs := state.GetAttr("reserved_ip_addresses")
+	if reservedIPs.IsNull() || reservedIPs.LengthInt() != 0 {
+		t.Errorf("reserved_ip_addresses = %#v, expected an empty set", reservedIPs)
+	}
+	if releaseIPs := state.GetAttr("release_addresses_on_destroy"); !releaseIPs.True() {
+		t.Errorf("release_addresses_on_destroy = %#v, expected true", releaseIPs)
+	}
+}
@@ -0,0 +1,130 @@
+package maas
+
+import (
+	"net"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/maas/gomaasclient/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDnsRecordData(t *testing.T) {
+	testCases := []struct {
+		name   string
+		rrType string
+		data   map[string]interface{}
+		rrData string
+	}{
+		{
+			name:   "MX record",
+			rrType: "MX",
+			data:   map[string]interface{}{"preference": 10, "exchange": "mail.example.com"},
+			rrData: "10 mail.example.com",
+		},
+		{
+			name:   "SRV record",
+			rrType: "SRV",
+			data:   map[string]interface{}{"priority": 0, "weight": 5, "port": 5060, "target": "sip.example.com"},
+			rrData: "0 5 5060 sip.example.com",
+		},
+		{
+			name:   "SSHFP record",
+			rrType: "SSHFP",
+			data:   map[string]interface{}{"algorithm": 4, "fingerprint_type": 1, "fingerprint": "123456789abcdef67890123456789abcdef67890"},
+			rrData: "4 1 123456789abcdef67890123456789abcdef67890",
+		},
+		{
+			name:   "TXT record",
+			rrType: "TXT",
+			data:   map[string]interface{}{"text": "v=spf1 mx -all"},
+			rrData: "v=spf1 mx -all",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rrData, err := expandDnsRecordData(testCase.rrType, testCase.data)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.rrData, rrData)
+			assert.Equal(t, []map[string]interface{}{testCase.data}, flattenDnsRecordData(testCase.rrType, testCase.rrData))
+		})
+	}
+}
+
+func TestDnsRecordDataErrors(t *testing.T) {
+	_, err := expandDnsRecordData("SSHFP", map[string]interface{}{"algorithm": 1, "fingerprint_type": 2, "fingerprint": "abcdef"})
+	assert.Error(t, err, "SHA-256 fingerprints must be 64 hexadecimal characters long")
+
+	_, err = expandDnsRecordData("CNAME", map[string]interface{}{})
+	assert.Error(t, err, "CNAME records have no structured data")
+
+	assert.Empty(t, flattenDnsRecordData("MX", "mail.example.com"), "MX data without preference can't be parsed")
+	assert.Empty(t, flattenDnsRecordData("SRV", "0 5 sip.example.com"), "SRV data without port can't be parsed")
+}
+
+func TestGetDnsResourceReservedIPAddresses(t *testing.T) {
+	dnsResource := &entity.DNSResource{
+		IPAddresses: []entity.IPAddress{
+			// Reserved by MAAS for the DNS resource
+			{IP: net.ParseIP("10.0.0.10"), AllocType: 4},
+			// Reserved before, e.g. by maas_ip_address
+			{IP: net.ParseIP("10.0.0.11"), AllocType: 4},
+			// Assigned to a machine
+			{IP: net.ParseIP("10.0.0.12"), AllocType: 1},
+			// Reserved by MAAS for the DNS resource, given in another notation
+			{IP: net.ParseIP("2001:db8::10"), AllocType: 4},
+		},
+	}
+
+	reservedIPs := getDnsResourceReservedIPAddresses(dnsResource, []string{"10.0.0.10", "10.0.0.12", "2001:DB8:0::10"})
+	assert.Equal(t, []string{"10.0.0.10", "2001:db8::10"}, reservedIPs)
+	assert.Empty(t, getDnsResourceReservedIPAddresses(dnsResource, []string{}))
+}
+
+func TestGetDnsResourceReleasedIPAddresses(t *testing.T) {
+	testCases := []struct {
+		name       string
+		attributes map[string]string
+		expected   []string
+	}{
+		{
+			name: "reserved IP addresses",
+			attributes: map[string]string{
+				"type":                         "A/AAAA",
+				"release_addresses_on_destroy": "true",
+				"reserved_ip_addresses.#":      "1",
+				"reserved_ip_addresses.0":      "10.0.0.10",
+			},
+			expected: []string{"10.0.0.10"},
+		},
+		{
+			name: "release disabled",
+			attributes: map[string]string{
+				"type":                         "A/AAAA",
+				"release_addresses_on_destroy": "false",
+				"reserved_ip_addresses.#":      "1",
+				"reserved_ip_addresses.0":      "10.0.0.10",
+			},
+		},
+		{
+			// A record upgraded from a provider version which didn't track
+			// the reserved IP addresses
+			name: "untracked reserved IP addresses",
+			attributes: map[string]string{
+				"type":                         "A/AAAA",
+				"data":                         "10.0.0.10 10.0.0.11",
+				"release_addresses_on_destroy": "true",
+				"reserved_ip_addresses.#":      "0",
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := resourceMaasDnsRecord().Data(&terraform.InstanceState{ID: "12", Attributes: testCase.attributes})
+			assert.Equal(t, testCase.expected, getDnsResourceReleasedIPAddresses(d))
+		})
+	}
+}
@@ -2,7 +2,9 @@ package maas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -34,11 +36,45 @@ func resourceMaasFabric() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"class_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The fabric class type (e.g. `10g`). The class type is matched by the `fabric_classes` allocation constraint.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The fabric description.",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The fabric name.",
 			},
+			"vlans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The VLANs of the fabric. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The VLAN database ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VLAN name.",
+						},
+						"vid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The VLAN traffic segregation ID.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -62,7 +98,25 @@ func resourceFabricRead(ctx context.Context, d *schema.ResourceData, meta interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if _, err := client.Fabric.Get(id); err != nil {
+	fabric, err := getFabricDetails(client, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vlans := make([]map[string]interface{}, len(fabric.VLANs))
+	for i, v := range fabric.VLANs {
+		vlans[i] = map[string]interface{}{
+			"id":   v.ID,
+			"name": v.Name,
+			"vid":  v.VID,
+		}
+	}
+	tfState := map[string]interface{}{
+		"class_type":  fabric.ClassType,
+		"description": fabric.Description,
+		"name":        fabric.Name,
+		"vlans":       vlans,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -79,6 +133,9 @@ func resourceFabricUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	if _, err := client.Fabric.Update(id, getFabricParams(d)); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := clearFabricDetails(client, d, id); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceFabricRead(ctx, d, meta)
 }
@@ -99,7 +156,9 @@ func resourceFabricDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 func getFabricParams(d *schema.ResourceData) *entity.FabricParams {
 	return &entity.FabricParams{
-		Name: d.Get("name").(string),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		ClassType:   d.Get("class_type").(string),
 	}
 }
 
@@ -126,3 +185,40 @@ func getFabric(client *client.Client, identifier string) (*entity.Fabric, error)
 	}
 	return fabric, nil
 }
+
+// fabricDetails holds the fabric fields which are missing from entity.Fabric.
+type fabricDetails struct {
+	entity.Fabric
+	Description string `json:"description"`
+}
+
+func getFabricDetails(client *client.Client, id int) (*fabricDetails, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	fabric := new(fabricDetails)
+	err = apiClient.GetSubObject("fabrics").GetSubObject(fmt.Sprintf("%v", id)).Get("", url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, fabric)
+	})
+	return fabric, err
+}
+
+// clearFabricDetails clears the fabric description and class type, which
+// entity.FabricParams omits when they are empty.
+func clearFabricDetails(client *client.Client, d *schema.ResourceData, id int) error {
+	params := url.Values{}
+	for _, k := range []string{"description", "class_type"} {
+		if d.HasChange(k) && d.Get(k).(string) == "" {
+			params.Set(k, "")
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	return apiClient.GetSubObject("fabrics").GetSubObject(fmt.Sprintf("%v", id)).Put(params, func(data []byte) error { return nil })
+}
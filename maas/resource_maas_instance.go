@@ -3,6 +3,8 @@ package maas
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -28,7 +30,25 @@ func resourceMaasInstance() *schema.Resource {
 				if machine.StatusName != "Deployed" {
 					return nil, fmt.Errorf("machine '%s' needs to be already deployed to be imported as maas_instance resource", machine.Hostname)
 				}
-				d.SetId(machine.SystemID)
+				tfState := map[string]interface{}{
+					"id": machine.SystemID,
+					"allocate_params": []map[string]interface{}{
+						{
+							"system_id": machine.SystemID,
+							"hostname":  machine.Hostname,
+						},
+					},
+					"deploy_params": []map[string]interface{}{
+						{
+							"distro_series":  machine.DistroSeries,
+							"hwe_kernel":     machine.HWEKernel,
+							"enable_hw_sync": machine.EnableHwSync,
+						},
+					},
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -36,11 +56,12 @@ func resourceMaasInstance() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"allocate_params": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				ForceNew:    true,
-				MaxItems:    1,
-				Description: "Nested argument with the constraints used to machine allocation. Defined below.",
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				MaxItems:         1,
+				DiffSuppressFunc: isAllocateParamsCompatible,
+				Description:      "Nested argument with the constraints used to machine allocation. Defined below. Changes are ignored if the already deployed machine satisfies the new constraints.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"hostname": {
@@ -99,11 +120,12 @@ func resourceMaasInstance() *schema.Resource {
 				Description: "The number of CPU cores of the deployed MAAS machine.",
 			},
 			"deploy_params": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				ForceNew:    true,
-				MaxItems:    1,
-				Description: "Nested argument with the config used to deploy the allocated machine. Defined below.",
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				MaxItems:         1,
+				DiffSuppressFunc: isDeployParamsCompatible,
+				Description:      "Nested argument with the config used to deploy the allocated machine. Defined below. Unset values are considered compatible with the already deployed machine.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"distro_series": {
@@ -222,14 +244,8 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta in
 	// Save system id
 	d.SetId(machine.SystemID)
 
-	// Configure network interfaces
-	err = configureInstanceNetworkInterfaces(client, d, machine)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Deploy MAAS machine
-	machine, err = client.Machine.Deploy(machine.SystemID, getMachineDeployParams(d))
+	// Configure network interfaces and deploy MAAS machine
+	machine, err = deployInstance(client, d, machine)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -278,7 +294,9 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, meta in
 	client := meta.(*client.Client)
 
 	// Release MAAS machine
+	machineLocks.Lock(d.Id())
 	err := client.Machines.Release([]string{d.Id()}, "Released by Terraform")
+	machineLocks.Unlock(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -327,6 +345,72 @@ func getMachineDeployParams(d *schema.ResourceData) *entity.MachineDeployParams
 	return &entity.MachineDeployParams{}
 }
 
+// isAllocateParamsCompatible suppresses the allocate_params diff of an existing
+// instance when the deployed machine satisfies the new allocation constraints.
+// Unset constraints are always compatible, so that imported instances are not
+// replaced on the first plan.
+func isAllocateParamsCompatible(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	if isUnsetInstanceParam(newValue) {
+		return true
+	}
+	keyParts := strings.Split(k, ".")
+	if len(keyParts) < 3 {
+		return false
+	}
+	switch keyParts[2] {
+	case "hostname":
+		return newValue == d.Get("hostname").(string)
+	case "system_id":
+		return newValue == d.Id()
+	case "pool":
+		return newValue == d.Get("pool").(string)
+	case "zone":
+		return newValue == d.Get("zone").(string)
+	case "min_cpu_count":
+		cpuCount, err := strconv.Atoi(newValue)
+		return err == nil && cpuCount <= d.Get("cpu_count").(int)
+	case "min_memory":
+		memory, err := strconv.Atoi(newValue)
+		return err == nil && memory <= d.Get("memory").(int)
+	case "tags":
+		machineTags := d.Get("tags").(*schema.Set)
+		for _, t := range d.Get("allocate_params.0.tags").(*schema.Set).List() {
+			if !machineTags.Contains(t) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isDeployParamsCompatible suppresses the deploy_params diff of an existing
+// instance when the new value is not set. In that case, the MAAS defaults are
+// used, and they are considered compatible with the deployed machine.
+func isDeployParamsCompatible(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	return isUnsetInstanceParam(newValue)
+}
+
+func isUnsetInstanceParam(value string) bool {
+	return value == "" || value == "0"
+}
+
+func deployInstance(client *client.Client, d *schema.ResourceData, machine *entity.Machine) (*entity.Machine, error) {
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	if err := configureInstanceNetworkInterfaces(client, d, machine); err != nil {
+		return nil, err
+	}
+	return client.Machine.Deploy(machine.SystemID, getMachineDeployParams(d))
+}
+
 func configureInstanceNetworkInterfaces(client *client.Client, d *schema.ResourceData, machine *entity.Machine) error {
 	for _, networkInterface := range d.Get("network_interfaces").(*schema.Set).List() {
 		n := networkInterface.(map[string]interface{})
@@ -0,0 +1,219 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/maas/gomaasclient/client"
+	"github.com/maas/gomaasclient/entity"
+)
+
+func resourceMaasIPAddress() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to reserve static IP addresses in MAAS subnets. This is useful for virtual IPs and appliances which are not MAAS machines or devices.",
+		CreateContext: resourceIPAddressCreate,
+		ReadContext:   resourceIPAddressRead,
+		DeleteContext: resourceIPAddressDelete,
+		CustomizeDiff: resourceIPAddressCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*client.Client)
+				ipAddress, err := getIPAddress(client, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":     ipAddress.IP.String(),
+					"subnet": ipAddress.Subnet.CIDR,
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The hostname (or FQDN) to be assigned to the reserved IP address. A DNS record is created for it. This argument is computed if it's not set.",
+			},
+			"ip_address": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+				Description:      "The IP address to be reserved. If it's not set, the next free IP address of the subnet is reserved. This argument is computed if it's not set.",
+			},
+			"mac_address": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsMACAddress),
+				Description:      "The MAC address to be associated with the reserved IP address. This argument is computed if it's not set.",
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (CIDR or ID) of the subnet the IP address is reserved in.",
+			},
+		},
+	}
+}
+
+func resourceIPAddressCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("ip_address") || !d.NewValueKnown("subnet") {
+		return nil
+	}
+	ipAddress := d.Get("ip_address").(string)
+	if ipAddress == "" {
+		return nil
+	}
+	cidr, err := getSubnetCIDR(meta.(*client.Client), d.Get("subnet").(string))
+	if err != nil {
+		return err
+	}
+	return validateIPAddressInCIDR("IP address", ipAddress, cidr)
+}
+
+func resourceIPAddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	subnet, err := getSubnet(client, d.Get("subnet").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ipAddress, err := reserveIPAddress(client, getIPAddressReserveParams(d, subnet.ID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(ipAddress.IP.String())
+
+	return resourceIPAddressRead(ctx, d, meta)
+}
+
+func resourceIPAddressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	ipAddress, err := getIPAddress(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Keep the subnet identifier given by the user, if it still matches
+	subnet := ipAddress.Subnet.CIDR
+	if p := d.Get("subnet").(string); p == fmt.Sprintf("%v", ipAddress.Subnet.ID) {
+		subnet = p
+	}
+	// Keep the MAC address given by the user, if it only differs in case
+	macAddress := ""
+	if len(ipAddress.InterfaceSet) > 0 {
+		macAddress = ipAddress.InterfaceSet[0].MACAddress
+		if p := d.Get("mac_address").(string); strings.EqualFold(p, macAddress) {
+			macAddress = p
+		}
+	}
+	hostname, err := getIPAddressHostname(client, ipAddress.IP, d.Get("hostname").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := map[string]interface{}{
+		"hostname":    hostname,
+		"ip_address":  ipAddress.IP.String(),
+		"mac_address": macAddress,
+		"subnet":      subnet,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceIPAddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	if err := client.IPAddresses.Release(&entity.IPAddressesParams{IP: d.Id()}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getIPAddressReserveParams(d *schema.ResourceData, subnetID int) url.Values {
+	params := url.Values{}
+	params.Set("subnet", fmt.Sprintf("%v", subnetID))
+	if p, ok := d.GetOk("ip_address"); ok {
+		params.Set("ip", p.(string))
+	}
+	if p, ok := d.GetOk("mac_address"); ok {
+		params.Set("mac", p.(string))
+	}
+	if p, ok := d.GetOk("hostname"); ok {
+		params.Set("hostname", p.(string))
+	}
+	return params
+}
+
+// reserveIPAddress works like client.IPAddresses.Reserve, but it supports the
+// mac and hostname parameters, which are missing from entity.IPAddressesParams.
+func reserveIPAddress(client *client.Client, params url.Values) (*entity.IPAddress, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	ipAddress := new(entity.IPAddress)
+	err = apiClient.GetSubObject("ipaddresses").Post("reserve", params, func(data []byte) error {
+		return json.Unmarshal(data, ipAddress)
+	})
+	return ipAddress, err
+}
+
+func getIPAddress(client *client.Client, ip string) (*entity.IPAddress, error) {
+	if net.ParseIP(ip) == nil {
+		return nil, fmt.Errorf("invalid IP address (%s)", ip)
+	}
+	ipAddresses, err := client.IPAddresses.Get(&entity.IPAddressesParams{IP: ip})
+	if err != nil {
+		return nil, err
+	}
+	for _, ipAddress := range ipAddresses {
+		if ipAddress.IP.Equal(net.ParseIP(ip)) {
+			return &ipAddress, nil
+		}
+	}
+	return nil, fmt.Errorf("IP address (%s) was not found", ip)
+}
+
+// getIPAddressHostname returns the FQDN of the DNS resource the IP address is
+// part of, or the hostname given by the user if it matches that FQDN.
+func getIPAddressHostname(client *client.Client, ip net.IP, hostname string) (string, error) {
+	dnsResources, err := client.DNSResources.Get()
+	if err != nil {
+		return "", err
+	}
+	for _, dnsResource := range dnsResources {
+		for _, ipAddress := range dnsResource.IPAddresses {
+			if !ipAddress.IP.Equal(ip) {
+				continue
+			}
+			if hostname == dnsResource.FQDN || strings.HasPrefix(dnsResource.FQDN, hostname+".") {
+				return hostname, nil
+			}
+			return dnsResource.FQDN, nil
+		}
+	}
+	return "", nil
+}
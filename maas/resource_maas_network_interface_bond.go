@@ -0,0 +1,290 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/maas/gomaasclient/client"
+	"github.com/maas/gomaasclient/entity"
+)
+
+var (
+	validBondModes            = []string{"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb"}
+	validBondLACPRates        = []string{"fast", "slow"}
+	validBondXMitHashPolicies = []string{"layer2", "layer2+3", "layer3+4", "encap2+3", "encap3+4"}
+)
+
+func resourceMaasNetworkInterfaceBond() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage a bond network interface from an existing MAAS machine.",
+		CreateContext: resourceNetworkInterfaceBondCreate,
+		ReadContext:   resourceNetworkInterfaceBondRead,
+		UpdateContext: resourceNetworkInterfaceBondUpdate,
+		DeleteContext: resourceNetworkInterfaceBondDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), ":")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:BOND", d.Id())
+				}
+				client := meta.(*client.Client)
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				n, err := getNetworkInterfaceWithType(client, machine.SystemID, idParts[1], "bond")
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":      fmt.Sprintf("%v", n.ID),
+					"machine": machine.SystemID,
+					"parents": n.Parents,
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bond_downdelay": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Specifies the time, in milliseconds, to wait before disabling a slave after a link failure has been detected. This argument is computed if it's not set.",
+			},
+			"bond_lacp_rate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validBondLACPRates, false)),
+				Description:      "Option specifying the rate at which to ask the link partner to transmit LACPDU packets in `802.3ad` mode. Valid options are: `fast`, `slow`. This argument is computed if it's not set.",
+			},
+			"bond_miimon": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The link monitoring frequency in milliseconds. This argument is computed if it's not set.",
+			},
+			"bond_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validBondModes, false)),
+				Description:      "The operating mode of the bond. Valid options are: `balance-rr`, `active-backup`, `balance-xor`, `broadcast`, `802.3ad`, `balance-tlb`, `balance-alb`. This argument is computed if it's not set.",
+			},
+			"bond_updelay": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Specifies the time, in milliseconds, to wait before enabling a slave after a link recovery has been detected. This argument is computed if it's not set.",
+			},
+			"bond_xmit_hash_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validBondXMitHashPolicies, false)),
+				Description:      "The transmit hash policy to use for slave selection in `balance-xor`, `802.3ad`, and `balance-tlb` modes. Valid options are: `layer2`, `layer2+3`, `layer3+4`, `encap2+3`, `encap3+4`. This argument is computed if it's not set.",
+			},
+			"mac_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The bond network interface MAC address. This argument is computed if it's not set, and it defaults to the MAC address of the first parent.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (system ID, hostname, or FQDN) of the machine with the bond network interface.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The MTU of the bond network interface. This argument is computed if it's not set.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The bond network interface name.",
+			},
+			"parents": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "A set of network interface identifiers (MAC address, name, or ID) to be bonded.",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "A set of tag names to be assigned to the bond network interface. This argument is computed if it's not set.",
+			},
+			"vlan": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Database ID of the VLAN the bond network interface is connected to. This argument is computed if it's not set.",
+			},
+		},
+	}
+}
+
+func resourceNetworkInterfaceBondCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	parents, err := getNetworkInterfaceIDs(client, machine.SystemID, convertToStringSlice(d.Get("parents").(*schema.Set).List()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkInterface, err := client.NetworkInterfaces.CreateBond(machine.SystemID, getNetworkInterfaceBondParams(d, parents))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", networkInterface.ID))
+
+	return resourceNetworkInterfaceBondRead(ctx, d, meta)
+}
+
+func resourceNetworkInterfaceBondRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkInterface, err := client.NetworkInterface.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	parents, err := flattenNetworkInterfaceParents(client, machine.SystemID, networkInterface, convertToStringSlice(d.Get("parents").(*schema.Set).List()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := getNetworkInterfaceParams(networkInterface)
+	tfState := map[string]interface{}{
+		"bond_downdelay":        getNetworkInterfaceParamInt(params, "bond_downdelay"),
+		"bond_lacp_rate":        getNetworkInterfaceParamString(params, "bond_lacp_rate"),
+		"bond_miimon":           getNetworkInterfaceParamInt(params, "bond_miimon"),
+		"bond_mode":             getNetworkInterfaceParamString(params, "bond_mode"),
+		"bond_updelay":          getNetworkInterfaceParamInt(params, "bond_updelay"),
+		"bond_xmit_hash_policy": getNetworkInterfaceParamString(params, "bond_xmit_hash_policy"),
+		"mac_address":           networkInterface.MACAddress,
+		"mtu":                   networkInterface.EffectiveMTU,
+		"name":                  networkInterface.Name,
+		"parents":               parents,
+		"tags":                  networkInterface.Tags,
+		"vlan":                  networkInterface.VLAN.ID,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceNetworkInterfaceBondUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	parents, err := getNetworkInterfaceIDs(client, machine.SystemID, convertToStringSlice(d.Get("parents").(*schema.Set).List()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := client.NetworkInterface.Update(machine.SystemID, id, getNetworkInterfaceBondUpdateParams(d, parents)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNetworkInterfaceBondRead(ctx, d, meta)
+}
+
+func resourceNetworkInterfaceBondDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.NetworkInterface.Delete(machine.SystemID, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getNetworkInterfaceBondParams(d *schema.ResourceData, parents []int) *entity.NetworkInterfaceBondParams {
+	return &entity.NetworkInterfaceBondParams{
+		Name:               d.Get("name").(string),
+		MACAddress:         d.Get("mac_address").(string),
+		Parents:            parents,
+		BondMode:           d.Get("bond_mode").(string),
+		BondMiimon:         d.Get("bond_miimon").(int),
+		BondDownDelay:      d.Get("bond_downdelay").(int),
+		BondUpDelay:        d.Get("bond_updelay").(int),
+		BondLACPRate:       d.Get("bond_lacp_rate").(string),
+		BondXMitHashPolicy: d.Get("bond_xmit_hash_policy").(string),
+		MTU:                d.Get("mtu").(int),
+		VLAN:               d.Get("vlan").(int),
+		Tags:               strings.Join(convertToStringSlice(d.Get("tags").(*schema.Set).List()), ","),
+	}
+}
+
+func getNetworkInterfaceBondUpdateParams(d *schema.ResourceData, parents []int) *entity.NetworkInterfaceUpdateParams {
+	return &entity.NetworkInterfaceUpdateParams{
+		Name:               d.Get("name").(string),
+		MACAddress:         d.Get("mac_address").(string),
+		Parents:            parents,
+		BondMode:           d.Get("bond_mode").(string),
+		BondMiimon:         d.Get("bond_miimon").(int),
+		BondDownDelay:      d.Get("bond_downdelay").(int),
+		BondUpDelay:        d.Get("bond_updelay").(int),
+		BondLACPRate:       d.Get("bond_lacp_rate").(string),
+		BondXMitHashPolicy: d.Get("bond_xmit_hash_policy").(string),
+		MTU:                d.Get("mtu").(int),
+		VLAN:               d.Get("vlan").(int),
+		Tags:               strings.Join(convertToStringSlice(d.Get("tags").(*schema.Set).List()), ","),
+	}
+}
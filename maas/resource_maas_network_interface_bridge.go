@@ -0,0 +1,274 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/maas/gomaasclient/client"
+	"github.com/maas/gomaasclient/entity"
+)
+
+func resourceMaasNetworkInterfaceBridge() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage a bridge network interface from an existing MAAS machine.",
+		CreateContext: resourceNetworkInterfaceBridgeCreate,
+		ReadContext:   resourceNetworkInterfaceBridgeRead,
+		UpdateContext: resourceNetworkInterfaceBridgeUpdate,
+		DeleteContext: resourceNetworkInterfaceBridgeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), ":")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:BRIDGE", d.Id())
+				}
+				client := meta.(*client.Client)
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				n, err := getNetworkInterfaceWithType(client, machine.SystemID, idParts[1], "bridge")
+				if err != nil {
+					return nil, err
+				}
+				if len(n.Parents) != 1 {
+					return nil, fmt.Errorf("bridge network interface (%s) has %v parents, expected 1", n.Name, len(n.Parents))
+				}
+				tfState := map[string]interface{}{
+					"id":      fmt.Sprintf("%v", n.ID),
+					"machine": machine.SystemID,
+					"parent":  n.Parents[0],
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bridge_fd": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The bridge forward delay, in seconds. This argument is computed if it's not set.",
+			},
+			"bridge_stp": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Boolean value indicating if the spanning tree protocol is enabled on the bridge. This argument is computed if it's not set.",
+			},
+			"bridge_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"standard", "ovs"}, false)),
+				Description:      "The bridge type. Valid options are: `standard`, `ovs`. This argument is computed if it's not set.",
+			},
+			"mac_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The bridge network interface MAC address. This argument is computed if it's not set, and it defaults to the MAC address of the parent.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (system ID, hostname, or FQDN) of the machine with the bridge network interface.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The MTU of the bridge network interface. This argument is computed if it's not set.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The bridge network interface name.",
+			},
+			"parent": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The identifier (MAC address, name, or ID) of the network interface (physical or bond) the bridge is created on.",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "A set of tag names to be assigned to the bridge network interface. This argument is computed if it's not set.",
+			},
+			"vlan": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Database ID of the VLAN the bridge network interface is connected to. This argument is computed if it's not set.",
+			},
+		},
+	}
+}
+
+func resourceNetworkInterfaceBridgeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	parents, err := getNetworkInterfaceIDs(client, machine.SystemID, []string{d.Get("parent").(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkInterface, err := client.NetworkInterfaces.CreateBridge(machine.SystemID, getNetworkInterfaceBridgeParams(d, parents))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", networkInterface.ID))
+
+	return resourceNetworkInterfaceBridgeRead(ctx, d, meta)
+}
+
+func resourceNetworkInterfaceBridgeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkInterface, err := client.NetworkInterface.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	parents, err := flattenNetworkInterfaceParents(client, machine.SystemID, networkInterface, []string{d.Get("parent").(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(parents) != 1 {
+		return diag.Errorf("bridge network interface (%s) has %v parents, expected 1", networkInterface.Name, len(parents))
+	}
+
+	params := getNetworkInterfaceParams(networkInterface)
+	tfState := map[string]interface{}{
+		"bridge_fd":   getNetworkInterfaceParamInt(params, "bridge_fd"),
+		"bridge_stp":  getNetworkInterfaceParamBool(params, "bridge_stp"),
+		"bridge_type": getNetworkInterfaceParamString(params, "bridge_type"),
+		"mac_address": networkInterface.MACAddress,
+		"mtu":         networkInterface.EffectiveMTU,
+		"name":        networkInterface.Name,
+		"parent":      parents[0],
+		"tags":        networkInterface.Tags,
+		"vlan":        networkInterface.VLAN.ID,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceNetworkInterfaceBridgeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	parents, err := getNetworkInterfaceIDs(client, machine.SystemID, []string{d.Get("parent").(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := client.NetworkInterface.Update(machine.SystemID, id, getNetworkInterfaceBridgeUpdateParams(d, parents)); err != nil {
+		return diag.FromErr(err)
+	}
+	// entity.NetworkInterfaceUpdateParams omits false values, so disabling STP is sent separately
+	if d.HasChange("bridge_stp") && !d.Get("bridge_stp").(bool) {
+		if err := disableNetworkInterfaceBridgeSTP(client, machine.SystemID, id); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceNetworkInterfaceBridgeRead(ctx, d, meta)
+}
+
+func resourceNetworkInterfaceBridgeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.NetworkInterface.Delete(machine.SystemID, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getNetworkInterfaceBridgeParams(d *schema.ResourceData, parents []int) *entity.NetworkInterfaceBridgeParams {
+	return &entity.NetworkInterfaceBridgeParams{
+		Name:       d.Get("name").(string),
+		MACAddress: d.Get("mac_address").(string),
+		Parents:    parents,
+		BridgeType: d.Get("bridge_type").(string),
+		BridgeSTP:  d.Get("bridge_stp").(bool),
+		BridgeFD:   d.Get("bridge_fd").(int),
+		MTU:        d.Get("mtu").(int),
+		VLAN:       d.Get("vlan").(int),
+		Tags:       strings.Join(convertToStringSlice(d.Get("tags").(*schema.Set).List()), ","),
+	}
+}
+
+func getNetworkInterfaceBridgeUpdateParams(d *schema.ResourceData, parents []int) *entity.NetworkInterfaceUpdateParams {
+	return &entity.NetworkInterfaceUpdateParams{
+		Name:       d.Get("name").(string),
+		MACAddress: d.Get("mac_address").(string),
+		Parents:    parents,
+		BridgeType: d.Get("bridge_type").(string),
+		BridgeSTP:  d.Get("bridge_stp").(bool),
+		BridgeFD:   d.Get("bridge_fd").(int),
+		MTU:        d.Get("mtu").(int),
+		VLAN:       d.Get("vlan").(int),
+		Tags:       strings.Join(convertToStringSlice(d.Get("tags").(*schema.Set).List()), ","),
+	}
+}
+
+func disableNetworkInterfaceBridgeSTP(client *client.Client, machineSystemID string, id int) error {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("bridge_stp", "false")
+	return apiClient.GetSubObject("nodes").GetSubObject(machineSystemID).GetSubObject("interfaces").GetSubObject(fmt.Sprintf("%v", id)).Put(params, func(data []byte) error { return nil })
+}
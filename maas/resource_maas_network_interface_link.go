@@ -2,8 +2,12 @@ package maas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,18 +18,76 @@ import (
 
 func resourceMaasNetworkInterfaceLink() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Provides a resource to manage network configuration on a network interface.",
+		Description:   "Provides a resource to manage network configuration on a network interface. Multiple links (e.g. an IPv4 and an IPv6 address, or links to different subnets) can be defined on the same network interface.",
 		CreateContext: resourceNetworkInterfaceLinkCreate,
 		ReadContext:   resourceNetworkInterfaceLinkRead,
 		UpdateContext: resourceNetworkInterfaceLinkUpdate,
 		DeleteContext: resourceNetworkInterfaceLinkDelete,
+		CustomizeDiff: resourceNetworkInterfaceLinkCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.SplitN(d.Id(), ":", 2)
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:INTERFACE:LINK_ID or MACHINE:INTERFACE:SUBNET_CIDR", d.Id())
+				}
+				client := meta.(*client.Client)
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				networkInterfaces, err := client.NetworkInterfaces.Get(machine.SystemID)
+				if err != nil {
+					return nil, err
+				}
+				networkInterface, interfaceIdentifier, linkIdentifier, err := splitNetworkInterfaceLinkImportID(networkInterfaces, idParts[1])
+				if err != nil {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:INTERFACE:LINK_ID or MACHINE:INTERFACE:SUBNET_CIDR: %w", d.Id(), err)
+				}
+				linkID, err := strconv.Atoi(linkIdentifier)
+				if err != nil {
+					linkID, err = getNetworkInterfaceLinkIDBySubnetCIDR(networkInterface, linkIdentifier)
+					if err != nil {
+						return nil, err
+					}
+				}
+				link, err := getNetworkInterfaceLink(client, machine.SystemID, networkInterface.ID, linkID)
+				if err != nil {
+					return nil, err
+				}
+				gatewayLinks, err := getMachineGatewayLinks(client, machine.SystemID)
+				if err != nil {
+					return nil, err
+				}
+				if link.Subnet.ID == 0 {
+					return nil, fmt.Errorf("link (%v) on the network interface (%s) is not connected to a subnet", link.ID, networkInterface.Name)
+				}
+				// Keep the subnet identifier in the form given by the user
+				subnet := fmt.Sprintf("%v", link.Subnet.ID)
+				if linkIdentifier == link.Subnet.CIDR {
+					subnet = link.Subnet.CIDR
+				}
+				tfState := map[string]interface{}{
+					"id":                fmt.Sprintf("%v", link.ID),
+					"machine":           machine.SystemID,
+					"network_interface": interfaceIdentifier,
+					"subnet":            subnet,
+					"mode":              strings.ToUpper(link.Mode),
+					"ip_address":        link.IPAddress,
+					"default_gateway":   gatewayLinks != nil && gatewayLinks.isDefaultGateway(link.ID),
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
 
 		Schema: map[string]*schema.Schema{
 			"default_gateway": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Boolean value. When enabled, it sets the subnet gateway IP address as the default gateway for the machine the interface belongs to. This option can only be used with the `AUTO` and `STATIC` modes. Defaults to `false`.",
+				Description: "Boolean value. When enabled, it sets the subnet gateway IP address as the default gateway for the machine the interface belongs to. The default gateway is managed per address family, so an IPv4 and an IPv6 link can both be enabled. When it changes from enabled to disabled, the default gateway is only cleared if this link owns it. It reflects the default gateway MAAS reports for the machine, which MAAS picks by itself when none is set, so set it explicitly on one link when the machine has several candidate links. This option can only be used with the `AUTO` and `STATIC` modes. Defaults to `false`.",
 			},
 			"ip_address": {
 				Type:             schema.TypeString,
@@ -65,6 +127,21 @@ func resourceMaasNetworkInterfaceLink() *schema.Resource {
 	}
 }
 
+func resourceNetworkInterfaceLinkCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("ip_address") || !d.NewValueKnown("subnet") {
+		return nil
+	}
+	ipAddress := d.Get("ip_address").(string)
+	if ipAddress == "" {
+		return nil
+	}
+	cidr, err := getSubnetCIDR(meta.(*client.Client), d.Get("subnet").(string))
+	if err != nil {
+		return err
+	}
+	return validateIPAddressInCIDR("IP address", ipAddress, cidr)
+}
+
 func resourceNetworkInterfaceLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client.Client)
 
@@ -73,6 +150,9 @@ func resourceNetworkInterfaceLinkCreate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
 	networkInterface, err := getNetworkInterface(client, machine.SystemID, d.Get("network_interface").(string))
 	if err != nil {
 		return diag.FromErr(err)
@@ -89,7 +169,12 @@ func resourceNetworkInterfaceLinkCreate(ctx context.Context, d *schema.ResourceD
 	// Save the resource id
 	d.SetId(fmt.Sprintf("%v", link.ID))
 
-	return resourceNetworkInterfaceLinkUpdate(ctx, d, meta)
+	// Set the default gateway
+	if err := updateNetworkInterfaceLinkDefaultGateway(client, d, machine.SystemID, networkInterface.ID, link.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNetworkInterfaceLinkRead(ctx, d, meta)
 }
 
 func resourceNetworkInterfaceLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -115,8 +200,23 @@ func resourceNetworkInterfaceLinkRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	// Report the links MAAS uses as default gateways. If MAAS doesn't expose
+	// them, the configured value is kept.
+	gatewayLinks, err := getMachineGatewayLinks(client, machine.SystemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defaultGateway := d.Get("default_gateway").(bool)
+	if gatewayLinks != nil {
+		defaultGateway = gatewayLinks.isDefaultGateway(link.ID)
+	}
+
 	// Set the Terraform state
-	if err := d.Set("ip_address", link.IPAddress); err != nil {
+	tfState := map[string]interface{}{
+		"ip_address":      link.IPAddress,
+		"default_gateway": defaultGateway,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -135,20 +235,18 @@ func resourceNetworkInterfaceLinkUpdate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
 	networkInterface, err := getNetworkInterface(client, machine.SystemID, d.Get("network_interface").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	// Run update operation
-	if _, err := client.Machine.ClearDefaultGateways(machine.SystemID); err != nil {
+	if err := updateNetworkInterfaceLinkDefaultGateway(client, d, machine.SystemID, networkInterface.ID, linkID); err != nil {
 		return diag.FromErr(err)
 	}
-	if d.Get("default_gateway").(bool) {
-		if _, err := client.NetworkInterface.SetDefaultGateway(machine.SystemID, networkInterface.ID, linkID); err != nil {
-			return diag.FromErr(err)
-		}
-	}
 
 	return resourceNetworkInterfaceLinkRead(ctx, d, meta)
 }
@@ -165,6 +263,9 @@ func resourceNetworkInterfaceLinkDelete(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
 	networkInterface, err := getNetworkInterface(client, machine.SystemID, d.Get("network_interface").(string))
 	if err != nil {
 		return diag.FromErr(err)
@@ -187,18 +288,151 @@ func getNetworkInterfaceLinkParams(d *schema.ResourceData, subnetID int) *entity
 	}
 }
 
+func updateNetworkInterfaceLinkDefaultGateway(client *client.Client, d *schema.ResourceData, machineSystemID string, networkInterfaceID int, linkID int) error {
+	if d.Get("default_gateway").(bool) {
+		// MAAS only replaces the default gateway of the link address family
+		_, err := client.NetworkInterface.SetDefaultGateway(machineSystemID, networkInterfaceID, linkID)
+		return err
+	}
+	// Only clear the default gateway when it's disabled in the configuration
+	if o, _ := d.GetChange("default_gateway"); !o.(bool) {
+		return nil
+	}
+	machine, err := client.Machine.Get(machineSystemID)
+	if err != nil {
+		return err
+	}
+	gatewayLinks := &machineGatewayLinks{
+		IPv4: machine.DefaultGateways.IPv4.LinkID,
+		IPv6: machine.DefaultGateways.IPv6.LinkID,
+	}
+	// Leave the default gateways alone, unless this link owns one of them
+	var otherLinkID int
+	switch linkID {
+	case gatewayLinks.IPv4:
+		otherLinkID = gatewayLinks.IPv6
+	case gatewayLinks.IPv6:
+		otherLinkID = gatewayLinks.IPv4
+	default:
+		return nil
+	}
+	// MAAS clears the default gateways of both address families at once, so the
+	// gateway of the other address family is set back afterwards
+	if _, err := client.Machine.ClearDefaultGateways(machineSystemID); err != nil {
+		return err
+	}
+	if otherLinkID == 0 {
+		return nil
+	}
+	for _, networkInterface := range machine.InterfaceSet {
+		for _, link := range networkInterface.Links {
+			if link.ID == otherLinkID {
+				_, err := client.NetworkInterface.SetDefaultGateway(machineSystemID, networkInterface.ID, otherLinkID)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// machineGatewayLinks holds the IDs of the links used as default gateways of a
+// machine, per address family. They are zero when there is no default gateway.
+type machineGatewayLinks struct {
+	IPv4 int
+	IPv6 int
+}
+
+func (g *machineGatewayLinks) isDefaultGateway(linkID int) bool {
+	return linkID != 0 && (g.IPv4 == linkID || g.IPv6 == linkID)
+}
+
+// getMachineGatewayLinks fetches the default gateway links of the machine. It
+// returns nil if MAAS doesn't return them.
+func getMachineGatewayLinks(client *client.Client, machineSystemID string) (*machineGatewayLinks, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	var gatewayLinks *machineGatewayLinks
+	err = apiClient.GetSubObject("machines").GetSubObject(machineSystemID).Get("", url.Values{}, func(data []byte) error {
+		var err error
+		gatewayLinks, err = parseMachineGatewayLinks(data)
+		return err
+	})
+	return gatewayLinks, err
+}
+
+// parseMachineGatewayLinks reads the link IDs from the default_gateways field
+// of a machine, e.g. {"ipv4": {"gateway_ip": "10.0.0.1", "link_id": 12}}.
+func parseMachineGatewayLinks(data []byte) (*machineGatewayLinks, error) {
+	var machine struct {
+		DefaultGateways *struct {
+			IPv4 struct {
+				LinkID *int `json:"link_id"`
+			} `json:"ipv4"`
+			IPv6 struct {
+				LinkID *int `json:"link_id"`
+			} `json:"ipv6"`
+		} `json:"default_gateways"`
+	}
+	if err := json.Unmarshal(data, &machine); err != nil {
+		return nil, err
+	}
+	if machine.DefaultGateways == nil {
+		return nil, nil
+	}
+	gatewayLinks := &machineGatewayLinks{}
+	if p := machine.DefaultGateways.IPv4.LinkID; p != nil {
+		gatewayLinks.IPv4 = *p
+	}
+	if p := machine.DefaultGateways.IPv6.LinkID; p != nil {
+		gatewayLinks.IPv6 = *p
+	}
+	return gatewayLinks, nil
+}
+
 func createNetworkInterfaceLink(client *client.Client, machineSystemID string, networkInterfaceID int, params *entity.NetworkInterfaceLinkParams) (*entity.NetworkInterfaceLink, error) {
-	// Clear existing links
-	_, err := client.NetworkInterface.Disconnect(machineSystemID, networkInterfaceID)
+	// Save the existing links, so the new one can be told apart from them
+	networkInterface, err := client.NetworkInterface.Get(machineSystemID, networkInterfaceID)
 	if err != nil {
 		return nil, err
 	}
+	existingLinkIDs := make(map[int]bool, len(networkInterface.Links))
+	for _, link := range networkInterface.Links {
+		existingLinkIDs[link.ID] = true
+	}
 	// Create new link
-	networkInterface, err := client.NetworkInterface.LinkSubnet(machineSystemID, networkInterfaceID, params)
+	networkInterface, err = client.NetworkInterface.LinkSubnet(machineSystemID, networkInterfaceID, params)
 	if err != nil {
 		return nil, err
 	}
-	return &networkInterface.Links[0], nil
+	link := findCreatedNetworkInterfaceLink(networkInterface.Links, existingLinkIDs, params)
+	if link == nil {
+		return nil, fmt.Errorf("cannot find the new link to subnet (%v) on the network interface (%v) from machine (%s)", params.Subnet, networkInterfaceID, machineSystemID)
+	}
+	return link, nil
+}
+
+// findCreatedNetworkInterfaceLink returns the link matching the subnet, mode and
+// IP address of params. Links not in existingLinkIDs are preferred, since MAAS
+// may reuse the ID of a replaced LINK_UP link for the new one.
+func findCreatedNetworkInterfaceLink(links []entity.NetworkInterfaceLink, existingLinkIDs map[int]bool, params *entity.NetworkInterfaceLinkParams) *entity.NetworkInterfaceLink {
+	var match *entity.NetworkInterfaceLink
+	for i, link := range links {
+		if link.Subnet.ID != params.Subnet || !strings.EqualFold(link.Mode, params.Mode) {
+			continue
+		}
+		if params.IPAddress != "" && link.IPAddress != params.IPAddress {
+			continue
+		}
+		if !existingLinkIDs[link.ID] {
+			return &links[i]
+		}
+		if match == nil {
+			match = &links[i]
+		}
+	}
+	return match
 }
 
 func getNetworkInterfaceLink(client *client.Client, machineSystemID string, networkInterfaceID int, linkID int) (*entity.NetworkInterfaceLink, error) {
@@ -214,6 +448,48 @@ func getNetworkInterfaceLink(client *client.Client, machineSystemID string, netw
 	return nil, fmt.Errorf("cannot find link (%v) on the network interface (%v) from machine (%s)", linkID, networkInterfaceID, machineSystemID)
 }
 
+// splitNetworkInterfaceLinkImportID splits the INTERFACE:LINK_ID or
+// INTERFACE:SUBNET_CIDR part of an import ID. Both MAC addresses and IPv6
+// CIDRs contain colons, so every split is tried until the first part matches
+// one of the machine network interfaces and the second one is a link ID or a
+// CIDR.
+func splitNetworkInterfaceLinkImportID(networkInterfaces []entity.NetworkInterface, id string) (*entity.NetworkInterface, string, string, error) {
+	for i, c := range id {
+		if c != ':' {
+			continue
+		}
+		interfaceIdentifier, linkIdentifier := id[:i], id[i+1:]
+		_, errLinkID := strconv.Atoi(linkIdentifier)
+		_, _, errCIDR := net.ParseCIDR(linkIdentifier)
+		if errLinkID != nil && errCIDR != nil {
+			continue
+		}
+		for _, n := range networkInterfaces {
+			if strings.EqualFold(n.MACAddress, interfaceIdentifier) || n.Name == interfaceIdentifier || fmt.Sprintf("%v", n.ID) == interfaceIdentifier {
+				return &n, interfaceIdentifier, linkIdentifier, nil
+			}
+		}
+	}
+	return nil, "", "", fmt.Errorf("no network interface matching (%s) was found", id)
+}
+
+func getNetworkInterfaceLinkIDBySubnetCIDR(networkInterface *entity.NetworkInterface, cidr string) (int, error) {
+	linkID := 0
+	for _, link := range networkInterface.Links {
+		if link.Subnet.CIDR != cidr {
+			continue
+		}
+		if linkID != 0 {
+			return 0, fmt.Errorf("network interface (%s) has multiple links to subnet (%s), import it by link ID instead", networkInterface.Name, cidr)
+		}
+		linkID = link.ID
+	}
+	if linkID == 0 {
+		return 0, fmt.Errorf("cannot find link to subnet (%s) on the network interface (%s)", cidr, networkInterface.Name)
+	}
+	return linkID, nil
+}
+
 func deleteNetworkInterfaceLink(client *client.Client, machineSystemID string, networkInterfaceID int, linkID int) error {
 	_, err := client.NetworkInterface.UnlinkSubnet(machineSystemID, networkInterfaceID, linkID)
 	return err
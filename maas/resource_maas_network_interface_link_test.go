@@ -0,0 +1,133 @@
+package maas
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/maas/gomaasclient/entity"
+)
+
+func TestFindCreatedNetworkInterfaceLink(t *testing.T) {
+	links := []entity.NetworkInterfaceLink{
+		{ID: 1, Mode: "static", IPAddress: "10.0.0.10", Subnet: entity.Subnet{ID: 1}},
+		{ID: 2, Mode: "auto", IPAddress: "2001:db8::10", Subnet: entity.Subnet{ID: 2}},
+		{ID: 3, Mode: "static", IPAddress: "10.0.0.11", Subnet: entity.Subnet{ID: 1}},
+	}
+	existingLinkIDs := map[int]bool{1: true, 2: true}
+
+	testCases := []struct {
+		params   *entity.NetworkInterfaceLinkParams
+		expected int
+	}{
+		{&entity.NetworkInterfaceLinkParams{Subnet: 1, Mode: "STATIC"}, 3},
+		{&entity.NetworkInterfaceLinkParams{Subnet: 1, Mode: "STATIC", IPAddress: "10.0.0.10"}, 1},
+		{&entity.NetworkInterfaceLinkParams{Subnet: 2, Mode: "AUTO"}, 2},
+		{&entity.NetworkInterfaceLinkParams{Subnet: 2, Mode: "DHCP"}, 0},
+		{&entity.NetworkInterfaceLinkParams{Subnet: 3, Mode: "AUTO"}, 0},
+	}
+	for _, tc := range testCases {
+		link := findCreatedNetworkInterfaceLink(links, existingLinkIDs, tc.params)
+		actual := 0
+		if link != nil {
+			actual = link.ID
+		}
+		if actual != tc.expected {
+			t.Errorf("findCreatedNetworkInterfaceLink(%+v) = %v, expected %v", tc.params, actual, tc.expected)
+		}
+	}
+}
+
+// machinePayload is a trimmed response of the MAAS machines endpoint
+const machinePayload = `{
+	"system_id": "4y3h7n",
+	"hostname": "vm1",
+	"fqdn": "vm1.maas",
+	"status_name": "Deployed",
+	"boot_interface": {"id": 7, "name": "eth0", "mac_address": "52:54:00:aa:bb:cc"},
+	"interface_set": [
+		{
+			"id": 7,
+			"name": "eth0",
+			"mac_address": "52:54:00:aa:bb:cc",
+			"links": [
+				{"id": 12, "mode": "auto", "ip_address": "10.0.0.10", "subnet": {"id": 1, "cidr": "10.0.0.0/24", "gateway_ip": "10.0.0.1"}},
+				{"id": 13, "mode": "auto", "ip_address": "2001:db8::10", "subnet": {"id": 2, "cidr": "2001:db8::/64", "gateway_ip": "2001:db8::1"}}
+			]
+		}
+	],
+	"default_gateways": %s,
+	"resource_uri": "/MAAS/api/2.0/machines/4y3h7n/"
+}`
+
+func TestParseMachineGatewayLinks(t *testing.T) {
+	testCases := []struct {
+		defaultGateways string
+		expected        *machineGatewayLinks
+	}{
+		{`{"ipv4": {"gateway_ip": null, "link_id": null}, "ipv6": {"gateway_ip": null, "link_id": null}}`, &machineGatewayLinks{}},
+		{`{"ipv4": {"gateway_ip": "10.0.0.1", "link_id": 12}, "ipv6": {"gateway_ip": null, "link_id": null}}`, &machineGatewayLinks{IPv4: 12}},
+		{`{"ipv4": {"gateway_ip": "10.0.0.1", "link_id": 12}, "ipv6": {"gateway_ip": "2001:db8::1", "link_id": 13}}`, &machineGatewayLinks{IPv4: 12, IPv6: 13}},
+	}
+	for _, tc := range testCases {
+		data := fmt.Sprintf(machinePayload, tc.defaultGateways)
+		actual, err := parseMachineGatewayLinks([]byte(data))
+		if err != nil {
+			t.Fatalf("parseMachineGatewayLinks(%s) returned an error: %v", tc.defaultGateways, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("parseMachineGatewayLinks(%s) = %+v, expected %+v", tc.defaultGateways, actual, tc.expected)
+		}
+	}
+
+	// The default gateways are unknown when MAAS doesn't return them
+	actual, err := parseMachineGatewayLinks([]byte(`{"system_id": "4y3h7n"}`))
+	if err != nil || actual != nil {
+		t.Errorf("parseMachineGatewayLinks() without default gateways = %+v, %v, expected nil", actual, err)
+	}
+
+	gatewayLinks := &machineGatewayLinks{IPv4: 12}
+	if !gatewayLinks.isDefaultGateway(12) || gatewayLinks.isDefaultGateway(13) || gatewayLinks.isDefaultGateway(0) {
+		t.Errorf("isDefaultGateway() mismatch for %+v", gatewayLinks)
+	}
+}
+
+func TestSplitNetworkInterfaceLinkImportID(t *testing.T) {
+	networkInterfaces := []entity.NetworkInterface{
+		{ID: 7, Name: "eth0", MACAddress: "52:54:00:aa:bb:cc"},
+		{ID: 8, Name: "eth1", MACAddress: "52:54:00:dd:ee:ff"},
+	}
+
+	testCases := []struct {
+		id                  string
+		interfaceIdentifier string
+		linkIdentifier      string
+		hasError            bool
+	}{
+		{"eth0:42", "eth0", "42", false},
+		{"8:10.0.0.0/24", "8", "10.0.0.0/24", false},
+		{"eth1:2001:db8::/64", "eth1", "2001:db8::/64", false},
+		{"52:54:00:aa:bb:cc:42", "52:54:00:aa:bb:cc", "42", false},
+		{"52:54:00:AA:BB:CC:10.0.0.0/24", "52:54:00:AA:BB:CC", "10.0.0.0/24", false},
+		{"52:54:00:dd:ee:ff:2001:db8::/64", "52:54:00:dd:ee:ff", "2001:db8::/64", false},
+		{"eth2:42", "", "", true},
+		{"eth0:link", "", "", true},
+		{"eth0", "", "", true},
+	}
+	for _, tc := range testCases {
+		networkInterface, interfaceIdentifier, linkIdentifier, err := splitNetworkInterfaceLinkImportID(networkInterfaces, tc.id)
+		if (err != nil) != tc.hasError {
+			t.Errorf("splitNetworkInterfaceLinkImportID(%s) returned error %v, expected error: %v", tc.id, err, tc.hasError)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if interfaceIdentifier != tc.interfaceIdentifier || linkIdentifier != tc.linkIdentifier {
+			t.Errorf("splitNetworkInterfaceLinkImportID(%s) = %s, %s, expected %s, %s", tc.id, interfaceIdentifier, linkIdentifier, tc.interfaceIdentifier, tc.linkIdentifier)
+		}
+		if networkInterface == nil {
+			t.Errorf("splitNetworkInterfaceLinkImportID(%s) returned no network interface", tc.id)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/maas/gomaasclient/client"
+	"github.com/maas/gomaasclient/entity"
+)
+
+func resourceMaasNetworkInterfaceVlan() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage a VLAN network interface from an existing MAAS machine.",
+		CreateContext: resourceNetworkInterfaceVlanCreate,
+		ReadContext:   resourceNetworkInterfaceVlanRead,
+		UpdateContext: resourceNetworkInterfaceVlanUpdate,
+		DeleteContext: resourceNetworkInterfaceVlanDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), ":")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:INTERFACE", d.Id())
+				}
+				client := meta.(*client.Client)
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				n, err := getNetworkInterfaceWithType(client, machine.SystemID, idParts[1], "vlan")
+				if err != nil {
+					return nil, err
+				}
+				if len(n.Parents) != 1 {
+					return nil, fmt.Errorf("VLAN network interface (%s) has %v parents, expected 1", n.Name, len(n.Parents))
+				}
+				tfState := map[string]interface{}{
+					"id":      fmt.Sprintf("%v", n.ID),
+					"machine": machine.SystemID,
+					"parent":  n.Parents[0],
+					"fabric":  fmt.Sprintf("%v", n.VLAN.FabricID),
+					"vlan":    fmt.Sprintf("%v", n.VLAN.VID),
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"fabric": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The identifier (name or ID) of the fabric of the VLAN.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (system ID, hostname, or FQDN) of the machine with the VLAN network interface.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The MTU of the VLAN network interface. This argument is computed if it's not set.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VLAN network interface name. MAAS names it after the parent and the VLAN traffic segregation ID (e.g. `bond0.100`).",
+			},
+			"parent": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (MAC address, name, or ID) of the parent network interface (physical, bond, or bridge) the VLAN network interface is created on.",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "A set of tag names to be assigned to the VLAN network interface. This argument is computed if it's not set.",
+			},
+			"vlan": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The identifier (ID or traffic segregation ID) of the VLAN the network interface is tagged for.",
+			},
+		},
+	}
+}
+
+func resourceNetworkInterfaceVlanCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	parents, err := getNetworkInterfaceIDs(client, machine.SystemID, []string{d.Get("parent").(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vlan, err := getNetworkInterfaceTaggedVlan(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkInterface, err := client.NetworkInterfaces.CreateVLAN(machine.SystemID, getNetworkInterfaceVlanParams(d, parents, vlan.ID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", networkInterface.ID))
+
+	return resourceNetworkInterfaceVlanRead(ctx, d, meta)
+}
+
+func resourceNetworkInterfaceVlanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkInterface, err := client.NetworkInterface.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	parents, err := flattenNetworkInterfaceParents(client, machine.SystemID, networkInterface, []string{d.Get("parent").(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(parents) != 1 {
+		return diag.Errorf("VLAN network interface (%s) has %v parents, expected 1", networkInterface.Name, len(parents))
+	}
+
+	// Keep the fabric and VLAN identifiers given by the user, if they still match
+	fabric := fmt.Sprintf("%v", networkInterface.VLAN.FabricID)
+	if p := d.Get("fabric").(string); p == networkInterface.VLAN.Fabric {
+		fabric = p
+	}
+	vlan := fmt.Sprintf("%v", networkInterface.VLAN.VID)
+	if p := d.Get("vlan").(string); p == fmt.Sprintf("%v", networkInterface.VLAN.ID) {
+		vlan = p
+	}
+	tfState := map[string]interface{}{
+		"fabric": fabric,
+		"mtu":    networkInterface.EffectiveMTU,
+		"name":   networkInterface.Name,
+		"parent": parents[0],
+		"tags":   networkInterface.Tags,
+		"vlan":   vlan,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceNetworkInterfaceVlanUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vlan, err := getNetworkInterfaceTaggedVlan(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := client.NetworkInterface.Update(machine.SystemID, id, getNetworkInterfaceVlanUpdateParams(d, vlan.ID)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNetworkInterfaceVlanRead(ctx, d, meta)
+}
+
+func resourceNetworkInterfaceVlanDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineLocks.Lock(machine.SystemID)
+	defer machineLocks.Unlock(machine.SystemID)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.NetworkInterface.Delete(machine.SystemID, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getNetworkInterfaceTaggedVlan(client *client.Client, d *schema.ResourceData) (*entity.VLAN, error) {
+	fabric, err := getFabric(client, d.Get("fabric").(string))
+	if err != nil {
+		return nil, err
+	}
+	return getVlan(client, fabric.ID, d.Get("vlan").(string))
+}
+
+func getNetworkInterfaceVlanParams(d *schema.ResourceData, parents []int, vlanID int) *entity.NetworkInterfaceVLANParams {
+	return &entity.NetworkInterfaceVLANParams{
+		Parents: parents,
+		VLAN:    vlanID,
+		MTU:     d.Get("mtu").(int),
+		Tags:    strings.Join(convertToStringSlice(d.Get("tags").(*schema.Set).List()), ","),
+	}
+}
+
+func getNetworkInterfaceVlanUpdateParams(d *schema.ResourceData, vlanID int) *entity.NetworkInterfaceUpdateParams {
+	return &entity.NetworkInterfaceUpdateParams{
+		VLAN: vlanID,
+		MTU:  d.Get("mtu").(int),
+		Tags: strings.Join(convertToStringSlice(d.Get("tags").(*schema.Set).List()), ","),
+	}
+}
@@ -2,7 +2,9 @@ package maas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -37,11 +39,40 @@ func resourceMaasSpace() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the new space.",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The name of the new space.",
 			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The subnets of the space. Parameters defined below.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet CIDR.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The subnet ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet name.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -65,7 +96,24 @@ func resourceSpaceRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if _, err := client.Space.Get(id); err != nil {
+	space, err := getSpaceDetails(client, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	subnets := make([]map[string]interface{}, len(space.Subnets))
+	for i, s := range space.Subnets {
+		subnets[i] = map[string]interface{}{
+			"cidr": s.CIDR,
+			"id":   s.ID,
+			"name": s.Name,
+		}
+	}
+	tfState := map[string]interface{}{
+		"description": space.Description,
+		"name":        space.Name,
+		"subnets":     subnets,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -82,6 +130,9 @@ func resourceSpaceUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	if _, err := client.Space.Update(id, d.Get("name").(string)); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := updateSpaceDescription(client, d, id); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceSpaceRead(ctx, d, meta)
 }
@@ -123,3 +174,37 @@ func getSpace(client *client.Client, identifier string) (*entity.Space, error) {
 	}
 	return space, nil
 }
+
+// spaceDetails holds the space fields which are missing from entity.Space.
+type spaceDetails struct {
+	entity.Space
+	Description string `json:"description"`
+}
+
+func getSpaceDetails(client *client.Client, id int) (*spaceDetails, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	space := new(spaceDetails)
+	err = apiClient.GetSubObject("spaces").GetSubObject(fmt.Sprintf("%v", id)).Get("", url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, space)
+	})
+	return space, err
+}
+
+// updateSpaceDescription updates the space description, which client.Space.Update
+// doesn't support.
+func updateSpaceDescription(client *client.Client, d *schema.ResourceData, id int) error {
+	if !d.HasChange("description") {
+		return nil
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("name", d.Get("name").(string))
+	params.Set("description", d.Get("description").(string))
+	return apiClient.GetSubObject("spaces").GetSubObject(fmt.Sprintf("%v", id)).Put(params, func(data []byte) error { return nil })
+}
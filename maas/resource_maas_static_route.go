@@ -0,0 +1,218 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/maas/gomaasclient/client"
+	"github.com/maas/gomaasclient/entity"
+)
+
+// staticRoute is a MAAS static route. gomaasclient doesn't implement the
+// static routes endpoints, so they are called through the generic API client.
+type staticRoute struct {
+	Source      entity.Subnet `json:"source,omitempty"`
+	Destination entity.Subnet `json:"destination,omitempty"`
+	GatewayIP   net.IP        `json:"gateway_ip,omitempty"`
+	Metric      int           `json:"metric"`
+	ID          int           `json:"id,omitempty"`
+}
+
+func resourceMaasStaticRoute() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage MAAS static routes. MAAS renders them into the network configuration of the deployed machines with an interface in the source subnet.",
+		CreateContext: resourceStaticRouteCreate,
+		ReadContext:   resourceStaticRouteRead,
+		UpdateContext: resourceStaticRouteUpdate,
+		DeleteContext: resourceStaticRouteDelete,
+		CustomizeDiff: resourceStaticRouteCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*client.Client)
+				id, err := strconv.Atoi(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				route, err := getStaticRoute(client, id)
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":          fmt.Sprintf("%v", route.ID),
+					"source":      fmt.Sprintf("%v", route.Source.ID),
+					"destination": fmt.Sprintf("%v", route.Destination.ID),
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"destination": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The identifier (CIDR or ID) of the destination subnet of the route.",
+			},
+			"gateway_ip": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+				Description:      "The IP address of the gateway on the source subnet.",
+			},
+			"metric": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The weight of the route on a deployed machine. This argument is computed if it's not set.",
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The identifier (CIDR or ID) of the source subnet of the route.",
+			},
+		},
+	}
+}
+
+func resourceStaticRouteCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("gateway_ip") || !d.NewValueKnown("source") {
+		return nil
+	}
+	cidr, err := getSubnetCIDR(meta.(*client.Client), d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+	return validateIPAddressInCIDR("gateway IP", d.Get("gateway_ip").(string), cidr)
+}
+
+func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	params, err := getStaticRouteParams(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	route := new(staticRoute)
+	err = apiClient.GetSubObject("static-routes").Post("", params, func(data []byte) error {
+		return json.Unmarshal(data, route)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", route.ID))
+
+	return resourceStaticRouteRead(ctx, d, meta)
+}
+
+func resourceStaticRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	route, err := getStaticRoute(client, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := map[string]interface{}{
+		"source":      flattenSubnetIdentifier(d.Get("source").(string), &route.Source),
+		"destination": flattenSubnetIdentifier(d.Get("destination").(string), &route.Destination),
+		"gateway_ip":  route.GatewayIP.String(),
+		"metric":      route.Metric,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params, err := getStaticRouteParams(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := getStaticRouteAPIClient(apiClient, id).Put(params, func(data []byte) error { return nil }); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStaticRouteRead(ctx, d, meta)
+}
+
+func resourceStaticRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := getStaticRouteAPIClient(apiClient, id).Delete(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getStaticRouteParams(client *client.Client, d *schema.ResourceData) (url.Values, error) {
+	source, err := getSubnet(client, d.Get("source").(string))
+	if err != nil {
+		return nil, err
+	}
+	destination, err := getSubnet(client, d.Get("destination").(string))
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("source", fmt.Sprintf("%v", source.ID))
+	params.Set("destination", fmt.Sprintf("%v", destination.ID))
+	params.Set("gateway_ip", d.Get("gateway_ip").(string))
+	if p, ok := d.GetOk("metric"); ok {
+		params.Set("metric", fmt.Sprintf("%v", p.(int)))
+	}
+	return params, nil
+}
+
+func getStaticRouteAPIClient(apiClient client.APIClient, id int) client.APIClient {
+	return apiClient.GetSubObject("static-routes").GetSubObject(fmt.Sprintf("%v", id))
+}
+
+func getStaticRoute(client *client.Client, id int) (*staticRoute, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	route := new(staticRoute)
+	err = getStaticRouteAPIClient(apiClient, id).Get("", url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, route)
+	})
+	return route, err
+}
@@ -2,9 +2,14 @@ package maas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -19,6 +24,7 @@ func resourceMaasSubnet() *schema.Resource {
 		ReadContext:   resourceSubnetRead,
 		UpdateContext: resourceSubnetUpdate,
 		DeleteContext: resourceSubnetDelete,
+		CustomizeDiff: resourceSubnetCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				client := meta.(*client.Client)
@@ -44,6 +50,12 @@ func resourceMaasSubnet() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"active_discovery": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value that indicates if MAAS actively scans this subnet to discover hosts. Defaults to `false`.",
+			},
 			"allow_dns": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -61,6 +73,20 @@ func resourceMaasSubnet() *schema.Resource {
 				Required:    true,
 				Description: "The subnet CIDR.",
 			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The subnet description.",
+			},
+			"disabled_boot_architectures": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "A set of boot architectures (e.g. `pxe`, `uefi_ebc_tftp`) that MAAS won't respond to on this subnet. This argument is computed if it's not set.",
+			},
 			"dns_servers": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -74,7 +100,8 @@ func resourceMaasSubnet() *schema.Resource {
 			"fabric": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "The fabric identifier (ID or name) for the new subnet.",
+				Computed:    true,
+				Description: "The fabric identifier (ID or name) for the new subnet. This argument is computed if it's not set.",
 			},
 			"gateway_ip": {
 				Type:             schema.TypeString,
@@ -86,7 +113,8 @@ func resourceMaasSubnet() *schema.Resource {
 			"ip_ranges": {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "A set of IP ranges configured on the new subnet. Parameters defined below. This argument is processed in [attribute-as-blocks mode](https://www.terraform.io/docs/configuration/attr-as-blocks.html).",
+				Computed:    true,
+				Description: "A set of IP ranges configured on the new subnet. Parameters defined below. Existing IP ranges are matched by start IP, end IP and type, so only the differences are applied. This argument is processed in [attribute-as-blocks mode](https://www.terraform.io/docs/configuration/attr-as-blocks.html). Removing the argument leaves the existing IP ranges untouched, so set it to `[]` to delete all of them. This argument is computed if it's not set.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"comment": {
@@ -115,10 +143,17 @@ func resourceMaasSubnet() *schema.Resource {
 					},
 				},
 			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Boolean value that indicates if MAAS manages the subnet. On unmanaged subnets, MAAS only allocates IP addresses from the reserved IP ranges. Defaults to `true`.",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "The subnet name.",
+				Computed:    true,
+				Description: "The subnet name. This argument is computed if it's not set.",
 			},
 			"rdns_mode": {
 				Type:             schema.TypeInt,
@@ -130,13 +165,61 @@ func resourceMaasSubnet() *schema.Resource {
 			"vlan": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				RequiredWith: []string{"fabric"},
-				Description:  "The VLAN identifier (ID or traffic segregation ID) for the new subnet. If this is set, the `fabric` argument is required.",
+				Description:  "The VLAN identifier (ID or traffic segregation ID) for the new subnet. If this is set, the `fabric` argument is required. This argument is computed if it's not set.",
 			},
 		},
 	}
 }
 
+func resourceSubnetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The computed IP ranges are kept when the argument is empty, so an
+	// explicitly empty set plans the deletion of the existing IP ranges
+	if isSubnetIPRangesConfiguredEmpty(d.GetRawConfig()) && d.Get("ip_ranges").(*schema.Set).Len() > 0 {
+		if err := d.SetNew("ip_ranges", []interface{}{}); err != nil {
+			return err
+		}
+	}
+	if !d.NewValueKnown("cidr") {
+		return nil
+	}
+	_, cidr, err := net.ParseCIDR(d.Get("cidr").(string))
+	if err != nil {
+		return err
+	}
+	gatewayIP := ""
+	if d.NewValueKnown("gateway_ip") {
+		gatewayIP = d.Get("gateway_ip").(string)
+		if err := validateIPAddressInCIDR("gateway IP", gatewayIP, cidr); err != nil {
+			return err
+		}
+	}
+	if !d.NewValueKnown("ip_ranges") {
+		return nil
+	}
+	ipRanges := []ipRange{}
+	for _, i := range d.Get("ip_ranges").(*schema.Set).List() {
+		ipr := i.(map[string]interface{})
+		startIP, endIP := net.ParseIP(ipr["start_ip"].(string)), net.ParseIP(ipr["end_ip"].(string))
+		if startIP == nil || endIP == nil {
+			continue
+		}
+		ipRanges = append(ipRanges, ipRange{Type: ipr["type"].(string), StartIP: startIP, EndIP: endIP})
+	}
+	if err := validateIPRanges(ipRanges, cidr); err != nil {
+		return err
+	}
+	if ip := net.ParseIP(gatewayIP); ip != nil {
+		for _, r := range ipRanges {
+			if r.Type == "dynamic" && r.contains(ip) {
+				return fmt.Errorf("gateway IP (%s) is in the dynamic IP range (%s)", gatewayIP, r)
+			}
+		}
+	}
+	return nil
+}
+
 func resourceSubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client.Client)
 
@@ -160,7 +243,7 @@ func resourceSubnetRead(ctx context.Context, d *schema.ResourceData, meta interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	subnet, err := client.Subnet.Get(id)
+	subnet, err := getSubnetDetails(client, id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -172,9 +255,33 @@ func resourceSubnetRead(ctx context.Context, d *schema.ResourceData, meta interf
 	for i, ip := range subnet.DNSServers {
 		dnsServers[i] = ip.String()
 	}
+	ipRanges, err := getSubnetIPRanges(client, subnet.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Keep the fabric and VLAN identifiers given by the user, if they still match
+	fabric := fmt.Sprintf("%v", subnet.VLAN.FabricID)
+	if p := d.Get("fabric").(string); p == subnet.VLAN.Fabric {
+		fabric = p
+	}
+	vlan := fmt.Sprintf("%v", subnet.VLAN.VID)
+	if p := d.Get("vlan").(string); p == fmt.Sprintf("%v", subnet.VLAN.ID) {
+		vlan = p
+	}
 	tfState := map[string]interface{}{
-		"gateway_ip":  gatewayIp,
-		"dns_servers": dnsServers,
+		"active_discovery":            subnet.ActiveDiscovery,
+		"allow_dns":                   subnet.AllowDNS,
+		"allow_proxy":                 subnet.AllowProxy,
+		"description":                 subnet.Description,
+		"disabled_boot_architectures": subnet.DisabledBootArchitectures,
+		"dns_servers":                 dnsServers,
+		"fabric":                      fabric,
+		"gateway_ip":                  gatewayIp,
+		"ip_ranges":                   flattenIPRanges(ipRanges),
+		"managed":                     subnet.Managed,
+		"name":                        subnet.Name,
+		"rdns_mode":                   subnet.RDNSMode,
+		"vlan":                        vlan,
 	}
 	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
@@ -197,6 +304,9 @@ func resourceSubnetUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	if _, err := client.Subnet.Update(id, params); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := updateSubnetDetails(client, d, id); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := updateIPRanges(client, d, id); err != nil {
 		return diag.FromErr(err)
 	}
@@ -219,25 +329,20 @@ func resourceSubnetDelete(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func updateIPRanges(client *client.Client, d *schema.ResourceData, subnetID int) error {
-	p, ok := d.GetOk("ip_ranges")
-	if !ok {
+	if !d.HasChange("ip_ranges") {
 		return nil
 	}
-	// Removing existing IP ranges on this subnet
-	ipRanges, err := client.IPRanges.Get()
+	existingIPRanges, err := getSubnetIPRanges(client, subnetID)
 	if err != nil {
 		return err
 	}
-	for _, ipr := range ipRanges {
-		if ipr.Subnet.ID != subnetID {
-			continue
-		}
-		if err := client.IPRange.Delete(ipr.ID); err != nil {
-			return err
-		}
+	// Match the existing IP ranges with the configured ones by start IP, end IP and type
+	existing := make(map[string]entity.IPRange, len(existingIPRanges))
+	for _, ipr := range existingIPRanges {
+		existing[getIPRangeKey(ipr.Type, ipr.StartIP.String(), ipr.EndIP.String())] = ipr
 	}
-	// Create the new IP ranges on this subnet
-	for _, i := range p.(*schema.Set).List() {
+	var toCreate []entity.IPRangeParams
+	for _, i := range d.Get("ip_ranges").(*schema.Set).List() {
 		ipr := i.(map[string]interface{})
 		params := entity.IPRangeParams{
 			Subnet:  fmt.Sprintf("%v", subnetID),
@@ -246,23 +351,105 @@ func updateIPRanges(client *client.Client, d *schema.ResourceData, subnetID int)
 			EndIP:   ipr["end_ip"].(string),
 			Comment: ipr["comment"].(string),
 		}
-		if _, err := client.IPRanges.Create(&params); err != nil {
+		key := getIPRangeKey(params.Type, params.StartIP, params.EndIP)
+		existingIPRange, ok := existing[key]
+		if !ok {
+			toCreate = append(toCreate, params)
+			continue
+		}
+		delete(existing, key)
+		if existingIPRange.Comment != params.Comment {
+			if err := updateIPRangeComment(client, existingIPRange.ID, params.Comment); err != nil {
+				return err
+			}
+		}
+	}
+	// Delete the IP ranges which are not configured anymore, before creating
+	// the new ones, so they can't overlap
+	for _, ipr := range existing {
+		if err := client.IPRange.Delete(ipr.ID); err != nil {
+			return err
+		}
+	}
+	for i := range toCreate {
+		if _, err := client.IPRanges.Create(&toCreate[i]); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// updateIPRangeComment updates the comment of an IP range through the generic
+// API client, since gomaasclient doesn't send empty comments.
+func updateIPRangeComment(client *client.Client, id int, comment string) error {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("comment", comment)
+	return apiClient.GetSubObject("ipranges").GetSubObject(fmt.Sprintf("%v", id)).Put(params, func(data []byte) error { return nil })
+}
+
+// isSubnetIPRangesConfiguredEmpty tells if the IP ranges are set to an empty
+// set in the configuration, unlike when they are not set at all.
+func isSubnetIPRangesConfiguredEmpty(rawConfig cty.Value) bool {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return false
+	}
+	v := rawConfig.GetAttr("ip_ranges")
+	return !v.IsNull() && v.IsKnown() && v.LengthInt() == 0
+}
+
+func getIPRangeKey(ipRangeType string, startIP string, endIP string) string {
+	// Normalize the IP addresses, so different notations of the same IPv6 address match
+	if ip := net.ParseIP(startIP); ip != nil {
+		startIP = ip.String()
+	}
+	if ip := net.ParseIP(endIP); ip != nil {
+		endIP = ip.String()
+	}
+	return fmt.Sprintf("%s:%s:%s", ipRangeType, startIP, endIP)
+}
+
+func getSubnetIPRanges(client *client.Client, subnetID int) ([]entity.IPRange, error) {
+	ipRanges, err := client.IPRanges.Get()
+	if err != nil {
+		return nil, err
+	}
+	subnetIPRanges := []entity.IPRange{}
+	for _, ipr := range ipRanges {
+		if ipr.Subnet.ID == subnetID {
+			subnetIPRanges = append(subnetIPRanges, ipr)
+		}
+	}
+	return subnetIPRanges, nil
+}
+
+func flattenIPRanges(ipRanges []entity.IPRange) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(ipRanges))
+	for i, ipr := range ipRanges {
+		result[i] = map[string]interface{}{
+			"type":     ipr.Type,
+			"start_ip": ipr.StartIP.String(),
+			"end_ip":   ipr.EndIP.String(),
+			"comment":  ipr.Comment,
+		}
+	}
+	return result
+}
+
 func getSubnetParams(client *client.Client, d *schema.ResourceData) (*entity.SubnetParams, error) {
 	params := entity.SubnetParams{
-		CIDR:       d.Get("cidr").(string),
-		Name:       d.Get("name").(string),
-		RDNSMode:   d.Get("rdns_mode").(int),
-		AllowDNS:   d.Get("allow_dns").(bool),
-		AllowProxy: d.Get("allow_proxy").(bool),
-		GatewayIP:  d.Get("gateway_ip").(string),
-		DNSServers: convertToStringSlice(d.Get("dns_servers")),
-		Managed:    true,
+		CIDR:        d.Get("cidr").(string),
+		Name:        d.Get("name").(string),
+		RDNSMode:    d.Get("rdns_mode").(int),
+		AllowDNS:    d.Get("allow_dns").(bool),
+		AllowProxy:  d.Get("allow_proxy").(bool),
+		GatewayIP:   d.Get("gateway_ip").(string),
+		DNSServers:  convertToStringSlice(d.Get("dns_servers")),
+		Managed:     d.Get("managed").(bool),
+		Description: d.Get("description").(string),
 	}
 	if p, ok := d.GetOk("fabric"); ok {
 		fabric, err := getFabric(client, p.(string))
@@ -305,3 +492,40 @@ func getSubnet(client *client.Client, identifier string) (*entity.Subnet, error)
 	}
 	return subnet, nil
 }
+
+// subnetDetails holds the subnet fields which are missing from entity.Subnet.
+type subnetDetails struct {
+	entity.Subnet
+	Description               string   `json:"description"`
+	DisabledBootArchitectures []string `json:"disabled_boot_architectures"`
+}
+
+func getSubnetDetails(client *client.Client, id int) (*subnetDetails, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	subnet := new(subnetDetails)
+	err = apiClient.GetSubObject("subnets").GetSubObject(fmt.Sprintf("%v", id)).Get("", url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, subnet)
+	})
+	return subnet, err
+}
+
+// updateSubnetDetails updates the subnet parameters which are missing from
+// entity.SubnetParams, along with the description, which can't be cleared
+// through it.
+func updateSubnetDetails(client *client.Client, d *schema.ResourceData, id int) error {
+	if !d.HasChanges("active_discovery", "description", "disabled_boot_architectures") {
+		return nil
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("active_discovery", strconv.FormatBool(d.Get("active_discovery").(bool)))
+	params.Set("description", d.Get("description").(string))
+	params.Set("disabled_boot_architectures", strings.Join(convertToStringSlice(d.Get("disabled_boot_architectures").(*schema.Set).List()), ","))
+	return apiClient.GetSubObject("subnets").GetSubObject(fmt.Sprintf("%v", id)).Put(params, func(data []byte) error { return nil })
+}
@@ -3,6 +3,7 @@ package maas
 import (
 	"context"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -20,6 +21,7 @@ func resourceMaasSubnetIPRange() *schema.Resource {
 		ReadContext:   resourceSubnetIPRangeRead,
 		UpdateContext: resourceSubnetIPRangeUpdate,
 		DeleteContext: resourceSubnetIPRangeDelete,
+		CustomizeDiff: resourceSubnetIPRangeCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				client := meta.(*client.Client)
@@ -92,6 +94,24 @@ func resourceMaasSubnetIPRange() *schema.Resource {
 	}
 }
 
+func resourceSubnetIPRangeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("start_ip") || !d.NewValueKnown("end_ip") {
+		return nil
+	}
+	startIP, endIP := net.ParseIP(d.Get("start_ip").(string)), net.ParseIP(d.Get("end_ip").(string))
+	if startIP == nil || endIP == nil {
+		return nil
+	}
+	var cidr *net.IPNet
+	if d.NewValueKnown("subnet") {
+		var err error
+		if cidr, err = getSubnetCIDR(meta.(*client.Client), d.Get("subnet").(string)); err != nil {
+			return err
+		}
+	}
+	return validateIPRanges([]ipRange{{Type: d.Get("type").(string), StartIP: startIP, EndIP: endIP}}, cidr)
+}
+
 func resourceSubnetIPRangeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client.Client)
 
@@ -2,7 +2,9 @@ package maas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,6 +20,7 @@ func resourceMaasVlan() *schema.Resource {
 		ReadContext:   resourceVlanRead,
 		UpdateContext: resourceVlanUpdate,
 		DeleteContext: resourceVlanDelete,
+		CustomizeDiff: resourceVlanCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), ":")
@@ -50,7 +53,12 @@ func resourceMaasVlan() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Computed:    true,
-				Description: "Boolean value. Whether or not DHCP should be managed on the new VLAN. This argument is computed if it's not set.",
+				Description: "Boolean value. Whether or not DHCP should be managed on the new VLAN. MAAS requires a primary rack controller and a dynamic IP range in one of the VLAN subnets before DHCP can be enabled, so this can only be enabled once the VLAN subnets exist, and not when the VLAN is created. This argument is computed if it's not set.",
+			},
+			"external_dhcp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address of the external DHCP server detected by MAAS on the VLAN, if any.",
 			},
 			"fabric": {
 				Type:        schema.TypeString,
@@ -70,6 +78,24 @@ func resourceMaasVlan() *schema.Resource {
 				Computed:    true,
 				Description: "The name of the new VLAN. This argument is computed if it's not set.",
 			},
+			"primary_rack": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The identifier (system ID or hostname) of the primary rack controller managing the VLAN. This argument is computed if it's not set.",
+			},
+			"relay_vlan": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Database ID of the VLAN the DHCP requests of this VLAN are relayed to. It can only be set when `dhcp_on` is disabled. Set it to `0` to stop relaying the DHCP requests. This argument is computed if it's not set.",
+			},
+			"secondary_rack": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The identifier (system ID or hostname) of the secondary rack controller managing the VLAN. This argument is computed if it's not set.",
+			},
 			"space": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -93,7 +119,11 @@ func resourceVlanCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	vlan, err := client.VLANs.Create(fabric.ID, getVlanParams(d))
+	params, err := getVlanParams(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vlan, err := client.VLANs.Create(fabric.ID, params)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -113,11 +143,19 @@ func resourceVlanRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	relayVlan := 0
+	if vlan.RelayVLAN != nil {
+		relayVlan = vlan.RelayVLAN.ID
+	}
 	tfState := map[string]interface{}{
-		"mtu":     vlan.MTU,
-		"dhcp_on": vlan.DHCPOn,
-		"name":    vlan.Name,
-		"space":   vlan.Space,
+		"mtu":            vlan.MTU,
+		"dhcp_on":        vlan.DHCPOn,
+		"external_dhcp":  vlan.ExternalDHCP,
+		"name":           vlan.Name,
+		"primary_rack":   flattenRackController(client, d.Get("primary_rack").(string), vlan.PrimaryRack),
+		"relay_vlan":     relayVlan,
+		"secondary_rack": flattenRackController(client, d.Get("secondary_rack").(string), vlan.SecondaryRack),
+		"space":          vlan.Space,
 	}
 	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
@@ -137,9 +175,22 @@ func resourceVlanUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if _, err := client.VLAN.Update(fabric.ID, vlan.VID, getVlanParams(d)); err != nil {
+	params, err := getVlanParams(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateVlanDHCP(client, vlan, params); err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := client.VLAN.Update(fabric.ID, vlan.VID, params); err != nil {
 		return diag.FromErr(err)
 	}
+	// The relay VLAN isn't sent when it's 0, so it's cleared separately
+	if d.HasChange("relay_vlan") && params.RelayVLAN == 0 && vlan.RelayVLAN != nil {
+		if err := clearVlanRelayVlan(client, fabric.ID, vlan.VID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	return resourceVlanRead(ctx, d, meta)
 }
@@ -162,14 +213,73 @@ func resourceVlanDelete(ctx context.Context, d *schema.ResourceData, meta interf
 	return nil
 }
 
-func getVlanParams(d *schema.ResourceData) *entity.VLANParams {
-	return &entity.VLANParams{
-		VID:    d.Get("vid").(int),
-		MTU:    d.Get("mtu").(int),
-		DHCPOn: d.Get("dhcp_on").(bool),
-		Name:   d.Get("name").(string),
-		Space:  d.Get("space").(string),
+func resourceVlanCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// A new VLAN has no subnets, so it can't have the dynamic IP range MAAS
+	// requires for DHCP
+	if d.Id() == "" && d.Get("dhcp_on").(bool) {
+		return fmt.Errorf("DHCP can't be enabled when the VLAN is created, since it requires a dynamic IP range in one of the VLAN subnets: set `dhcp_on` once the subnet and its dynamic IP range exist")
 	}
+	return nil
+}
+
+func getVlanParams(client *client.Client, d *schema.ResourceData) (*entity.VLANParams, error) {
+	params := entity.VLANParams{
+		VID:       d.Get("vid").(int),
+		MTU:       d.Get("mtu").(int),
+		DHCPOn:    d.Get("dhcp_on").(bool),
+		Name:      d.Get("name").(string),
+		Space:     d.Get("space").(string),
+		RelayVLAN: d.Get("relay_vlan").(int),
+	}
+	if p, ok := d.GetOk("primary_rack"); ok {
+		rack, err := getRackController(client, p.(string))
+		if err != nil {
+			return nil, err
+		}
+		params.PrimaryRack = rack.SystemID
+	}
+	if p, ok := d.GetOk("secondary_rack"); ok {
+		rack, err := getRackController(client, p.(string))
+		if err != nil {
+			return nil, err
+		}
+		params.SecondaryRack = rack.SystemID
+	}
+	return &params, nil
+}
+
+// validateVlanDHCP checks the MAAS prerequisites for enabling DHCP on the
+// VLAN, so they are reported before the VLAN is updated.
+func validateVlanDHCP(client *client.Client, vlan *entity.VLAN, params *entity.VLANParams) error {
+	if !params.DHCPOn || vlan.DHCPOn {
+		return nil
+	}
+	if params.RelayVLAN != 0 {
+		return fmt.Errorf("DHCP can't be enabled on VLAN (%v) when it's relayed to another VLAN", vlan.VID)
+	}
+	if params.PrimaryRack == "" && vlan.PrimaryRack == "" {
+		return fmt.Errorf("DHCP can't be enabled on VLAN (%v) without a primary rack controller", vlan.VID)
+	}
+	ipRanges, err := client.IPRanges.Get()
+	if err != nil {
+		return err
+	}
+	for _, ipr := range ipRanges {
+		if ipr.Type == "dynamic" && ipr.Subnet.VLAN.ID == vlan.ID {
+			return nil
+		}
+	}
+	return fmt.Errorf("DHCP can't be enabled on VLAN (%v) before a dynamic IP range exists in one of its subnets", vlan.VID)
+}
+
+func clearVlanRelayVlan(client *client.Client, fabricID int, vid int) error {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("relay_vlan", "")
+	return apiClient.GetSubObject("fabrics").GetSubObject(fmt.Sprintf("%v", fabricID)).GetSubObject("vlans").GetSubObject(fmt.Sprintf("%v", vid)).Put(params, func(data []byte) error { return nil })
 }
 
 func findVlan(client *client.Client, fabricID int, identifier string) (*entity.VLAN, error) {
@@ -195,3 +305,45 @@ func getVlan(client *client.Client, fabricID int, identifier string) (*entity.VL
 	}
 	return vlan, nil
 }
+
+// rackController holds the rack controller fields used by the provider. The
+// rack controllers are listed through the generic API client, since
+// gomaasclient doesn't implement the rack controllers endpoint.
+type rackController struct {
+	SystemID string `json:"system_id"`
+	Hostname string `json:"hostname"`
+	FQDN     string `json:"fqdn"`
+}
+
+func getRackController(client *client.Client, identifier string) (*rackController, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	racks := []rackController{}
+	err = apiClient.GetSubObject("rackcontrollers").Get("", url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, &racks)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range racks {
+		if r.SystemID == identifier || r.Hostname == identifier || r.FQDN == identifier {
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("rack controller (%s) was not found", identifier)
+}
+
+// flattenRackController returns the rack controller identifier given by the
+// user, if it still matches the rack controller with the given system ID.
+func flattenRackController(client *client.Client, identifier string, systemID string) string {
+	if identifier == "" || identifier == systemID || systemID == "" {
+		return systemID
+	}
+	rack, err := getRackController(client, identifier)
+	if err != nil || rack.SystemID != systemID {
+		return systemID
+	}
+	return identifier
+}
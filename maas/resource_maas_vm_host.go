@@ -66,10 +66,11 @@ func resourceMaasVMHost() *schema.Resource {
 				Description: "The new VM host CPU overcommit ratio. This is computed if it's not set.",
 			},
 			"default_macvlan_mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "The new VM host default macvlan mode. Supported values are: `bridge`, `passthru`, `private`, `vepa`. This is computed if it's not set.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"bridge", "passthru", "private", "vepa"}, false)),
+				Description:      "The new VM host default macvlan mode. Supported values are: `bridge`, `passthru`, `private`, `vepa`. The `bridge` mode attaches the VMs to a bridge network interface of the VM host (e.g. one managed with `maas_network_interface_bridge`). This is computed if it's not set.",
 			},
 			"machine": {
 				Type:          schema.TypeString,
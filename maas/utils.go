@@ -1,9 +1,15 @@
 package maas
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-cty/cty/gocty"
@@ -39,6 +45,35 @@ func convertToStringSlice(field interface{}) []string {
 	return result
 }
 
+// normalizeIPAddress returns the canonical form of an IP address, so that
+// different spellings (e.g. `2001:DB8::0001` and `2001:db8::1`) don't diff.
+func normalizeIPAddress(v interface{}) string {
+	if ip := net.ParseIP(v.(string)); ip != nil {
+		return ip.String()
+	}
+	return v.(string)
+}
+
+func normalizeIPAddresses(ips []string) []string {
+	result := make([]string, len(ips))
+	for i, ip := range ips {
+		result[i] = normalizeIPAddress(ip)
+	}
+	return result
+}
+
+func hashIPAddress(v interface{}) int {
+	return schema.HashString(normalizeIPAddress(v))
+}
+
+func newStringSet(values []string) *schema.Set {
+	items := make([]interface{}, len(values))
+	for i, value := range values {
+		items[i] = value
+	}
+	return schema.NewSet(schema.HashString, items)
+}
+
 func isElementIPAddress(i interface{}, p cty.Path) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -90,7 +125,7 @@ func isEmailAddress(i interface{}, p cty.Path) diag.Diagnostics {
 	return diags
 }
 
-func getNetworkInterface(client *client.Client, machineSystemID string, identifier string) (*entity.NetworkInterface, error) {
+func findNetworkInterface(client *client.Client, machineSystemID string, identifier string) (*entity.NetworkInterface, error) {
 	networkInterfaces, err := client.NetworkInterfaces.Get(machineSystemID)
 	if err != nil {
 		return nil, err
@@ -100,7 +135,105 @@ func getNetworkInterface(client *client.Client, machineSystemID string, identifi
 			return &n, nil
 		}
 	}
-	return nil, fmt.Errorf("network interface (%s) was not found on machine (%s)", identifier, machineSystemID)
+	return nil, nil
+}
+
+func getNetworkInterface(client *client.Client, machineSystemID string, identifier string) (*entity.NetworkInterface, error) {
+	n, err := findNetworkInterface(client, machineSystemID, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return nil, fmt.Errorf("network interface (%s) was not found on machine (%s)", identifier, machineSystemID)
+	}
+	return n, nil
+}
+
+func getNetworkInterfaceWithType(client *client.Client, machineSystemID string, identifier string, networkInterfaceType string) (*entity.NetworkInterface, error) {
+	n, err := getNetworkInterface(client, machineSystemID, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if n.Type != networkInterfaceType {
+		return nil, fmt.Errorf("network interface (%s) from machine (%s) has type %s, expected %s", identifier, machineSystemID, n.Type, networkInterfaceType)
+	}
+	return n, nil
+}
+
+func getNetworkInterfaceIDs(client *client.Client, machineSystemID string, identifiers []string) ([]int, error) {
+	ids := make([]int, len(identifiers))
+	for i, identifier := range identifiers {
+		n, err := getNetworkInterface(client, machineSystemID, identifier)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = n.ID
+	}
+	return ids, nil
+}
+
+// flattenNetworkInterfaceParents returns the parents of the given network interface.
+// The parents already referenced by the given identifiers (MAC address, name, or ID)
+// keep their identifier, so that no diff is shown. Otherwise, the parent name is used.
+func flattenNetworkInterfaceParents(client *client.Client, machineSystemID string, networkInterface *entity.NetworkInterface, identifiers []string) ([]string, error) {
+	networkInterfaces, err := client.NetworkInterfaces.Get(machineSystemID)
+	if err != nil {
+		return nil, err
+	}
+	parents := make([]string, len(networkInterface.Parents))
+	for i, name := range networkInterface.Parents {
+		parents[i] = name
+		for _, n := range networkInterfaces {
+			if n.Name != name {
+				continue
+			}
+			for _, identifier := range identifiers {
+				if n.MACAddress == identifier || n.Name == identifier || fmt.Sprintf("%v", n.ID) == identifier {
+					parents[i] = identifier
+					break
+				}
+			}
+			break
+		}
+	}
+	return parents, nil
+}
+
+// getNetworkInterfaceParams returns the type specific parameters (e.g. bond or bridge options)
+// of the given network interface.
+func getNetworkInterfaceParams(networkInterface *entity.NetworkInterface) map[string]interface{} {
+	params, ok := networkInterface.Params.(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return params
+}
+
+func getNetworkInterfaceParamInt(params map[string]interface{}, key string) int {
+	switch v := params[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case json.Number:
+		i, _ := v.Int64()
+		return int(i)
+	}
+	return 0
+}
+
+func getNetworkInterfaceParamString(params map[string]interface{}, key string) string {
+	if v, ok := params[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func getNetworkInterfaceParamBool(params map[string]interface{}, key string) bool {
+	if v, ok := params[key].(bool); ok {
+		return v
+	}
+	return false
 }
 
 func setTerraformState(d *schema.ResourceData, tfState map[string]interface{}) error {
@@ -120,3 +253,109 @@ func setTerraformState(d *schema.ResourceData, tfState map[string]interface{}) e
 	}
 	return nil
 }
+
+// getSubnetCIDR returns the CIDR of the subnet with the given identifier (CIDR
+// or ID). It returns nil if the subnet doesn't exist yet.
+func getSubnetCIDR(client *client.Client, identifier string) (*net.IPNet, error) {
+	if _, cidr, err := net.ParseCIDR(identifier); err == nil {
+		return cidr, nil
+	}
+	subnet, err := findSubnet(client, identifier)
+	if err != nil || subnet == nil {
+		return nil, err
+	}
+	_, cidr, err := net.ParseCIDR(subnet.CIDR)
+	return cidr, err
+}
+
+func validateIPAddressInCIDR(attribute string, ipAddress string, cidr *net.IPNet) error {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil || cidr == nil {
+		return nil
+	}
+	if !cidr.Contains(ip) {
+		return fmt.Errorf("%s (%s) is not in the subnet CIDR (%s)", attribute, ipAddress, cidr)
+	}
+	return nil
+}
+
+type ipRange struct {
+	Type    string
+	StartIP net.IP
+	EndIP   net.IP
+}
+
+func (r ipRange) String() string {
+	return fmt.Sprintf("%s->%s", r.StartIP, r.EndIP)
+}
+
+func (r ipRange) contains(ip net.IP) bool {
+	return bytes.Compare(r.StartIP.To16(), ip.To16()) <= 0 && bytes.Compare(ip.To16(), r.EndIP.To16()) <= 0
+}
+
+// validateIPRanges checks that the IP ranges are in the subnet CIDR (if it's
+// known), that each start IP is not above its end IP, and that they don't
+// overlap each other.
+func validateIPRanges(ipRanges []ipRange, cidr *net.IPNet) error {
+	for _, r := range ipRanges {
+		if err := validateIPAddressInCIDR("start IP of the IP range", r.StartIP.String(), cidr); err != nil {
+			return err
+		}
+		if err := validateIPAddressInCIDR("end IP of the IP range", r.EndIP.String(), cidr); err != nil {
+			return err
+		}
+		if bytes.Compare(r.StartIP.To16(), r.EndIP.To16()) > 0 {
+			return fmt.Errorf("start IP of the IP range (%s) is above its end IP", r)
+		}
+	}
+	sorted := make([]ipRange, len(ipRanges))
+	copy(sorted, ipRanges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].StartIP.To16(), sorted[j].StartIP.To16()) < 0
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].contains(sorted[i].StartIP) {
+			return fmt.Errorf("IP range (%s) overlaps IP range (%s)", sorted[i], sorted[i-1])
+		}
+	}
+	return nil
+}
+
+// getAPIClient returns the generic MAAS API client behind c, which is used to
+// call the MAAS API operations that gomaasclient doesn't implement.
+func getAPIClient(c *client.Client) (client.APIClient, error) {
+	ipAddresses, ok := c.IPAddresses.(*client.IPAddresses)
+	if !ok {
+		return client.APIClient{}, fmt.Errorf("unexpected MAAS client implementation (%T)", c.IPAddresses)
+	}
+	return ipAddresses.APIClient, nil
+}
+
+// flattenSubnetIdentifier returns the subnet identifier (CIDR or ID) given by
+// the user, if it still matches subnet, or the subnet ID otherwise.
+func flattenSubnetIdentifier(identifier string, subnet *entity.Subnet) string {
+	if identifier == subnet.CIDR {
+		return identifier
+	}
+	return fmt.Sprintf("%v", subnet.ID)
+}
+
+// getDataSourceListID returns a stable ID for a data source listing the MAAS
+// objects with the given IDs.
+func getDataSourceListID(ids []int) string {
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = fmt.Sprintf("%v", id)
+	}
+	return fmt.Sprintf("%v", schema.HashString(strings.Join(idStrings, ",")))
+}
+
+// getNameRegexFilter compiles the name_regex argument of a data source. It
+// returns nil if the argument isn't set.
+func getNameRegexFilter(d *schema.ResourceData) (*regexp.Regexp, error) {
+	p, ok := d.GetOk("name_regex")
+	if !ok {
+		return nil, nil
+	}
+	return regexp.Compile(p.(string))
+}
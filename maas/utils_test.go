@@ -2,6 +2,7 @@ package maas
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"testing"
 
@@ -66,3 +67,83 @@ func TestConvertToStringSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateIPRanges(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/24")
+	newIPRange := func(startIP string, endIP string) ipRange {
+		return ipRange{Type: "dynamic", StartIP: net.ParseIP(startIP), EndIP: net.ParseIP(endIP)}
+	}
+	testCases := []struct {
+		name     string
+		in       []ipRange
+		cidr     *net.IPNet
+		hasError bool
+	}{
+		{
+			name: "valid IP ranges",
+			in:   []ipRange{newIPRange("10.0.0.100", "10.0.0.200"), newIPRange("10.0.0.10", "10.0.0.99")},
+			cidr: cidr,
+		},
+		{
+			name:     "IP range outside CIDR",
+			in:       []ipRange{newIPRange("10.0.0.100", "10.0.1.10")},
+			cidr:     cidr,
+			hasError: true,
+		},
+		{
+			name: "unknown CIDR",
+			in:   []ipRange{newIPRange("10.0.0.100", "10.0.1.10")},
+		},
+		{
+			name:     "start IP above end IP",
+			in:       []ipRange{newIPRange("10.0.0.200", "10.0.0.100")},
+			cidr:     cidr,
+			hasError: true,
+		},
+		{
+			name:     "overlapping IP ranges",
+			in:       []ipRange{newIPRange("10.0.0.100", "10.0.0.200"), newIPRange("10.0.0.10", "10.0.0.100")},
+			cidr:     cidr,
+			hasError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateIPRanges(testCase.in, testCase.cidr)
+			assert.Equal(t, testCase.hasError, err != nil, fmt.Sprintf("validateIPRanges(%s) => %v", testCase.in, err))
+		})
+	}
+}
+
+func TestNormalizeIPAddress(t *testing.T) {
+	testCases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "IPv4 address",
+			in:   "10.0.0.1",
+			out:  "10.0.0.1",
+		},
+		{
+			name: "IPv6 address",
+			in:   "2001:DB8:0::0001",
+			out:  "2001:db8::1",
+		},
+		{
+			name: "invalid IP address",
+			in:   "not-an-ip",
+			out:  "not-an-ip",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			out := normalizeIPAddress(testCase.in)
+			assert.Equal(t, testCase.out, out, fmt.Sprintf("normalizeIPAddress(%s) => %s, want %s", testCase.in, out, testCase.out))
+			assert.Equal(t, hashIPAddress(testCase.out), hashIPAddress(testCase.in))
+		})
+	}
+}